@@ -1,6 +1,11 @@
 package goiter
 
-import "iter"
+import (
+    "cmp"
+    "iter"
+    "math"
+    "math/bits"
+)
 
 // Count counts the number of elements yielded by the input iterator.
 func Count[TIter SeqX[T], T any](iterator TIter) int {
@@ -20,6 +25,222 @@ func Count2[TIter Seq2X[T1, T2], T1 any, T2 any](iterator TIter) int {
     return count
 }
 
+// Sum returns the sum of the values provided by the input iterator, or 0 if the input iterator yields nothing.
+// Integers are accumulated in 128 bits and floating-point numbers in float64, so the intermediate sums never overflow,
+// and if the final sum doesn't fit in T, it saturates at the minimum or maximum value of T instead of wrapping around.
+// For example:
+//
+//  goiter.Sum(goiter.Items[int8](100, 100))         // returns 127
+//  goiter.Sum(goiter.Items[int8](100, 100, -100))   // returns 100
+func Sum[TIter SeqX[T], T Number](iterator TIter) T {
+    var zero T
+    if T(1)/T(2) != zero {
+        sum := 0.0
+        for v := range iterator {
+            sum += float64(v)
+        }
+        return T(sum)
+    }
+
+    signed := zero-1 < zero
+    var sum wideSum
+    for v := range iterator {
+        if signed {
+            sum.addInt(int64(v))
+        } else {
+            sum.addUint(uint64(v))
+        }
+    }
+    minT, maxT := intLimits[T]()
+    if int64(sum.hi) < 0 {
+        if sum.hi == math.MaxUint64 && int64(sum.lo) < 0 && int64(sum.lo) >= minT {
+            return T(int64(sum.lo))
+        }
+        return T(minT)
+    }
+    if sum.hi == 0 && sum.lo <= maxT {
+        return T(sum.lo)
+    }
+    return T(maxT)
+}
+
+// Product returns the product of the values provided by the input iterator, or 1 if the input iterator yields nothing.
+// Like Sum, floating-point numbers are accumulated in float64, and for integers, if the product doesn't fit in T,
+// it saturates at the minimum or maximum value of T according to its sign instead of wrapping around.
+// For integers, it stops consuming the input iterator once a zero is found, since the product is 0 anyway.
+// Floating-point inputs are always consumed entirely, since a later infinity or NaN makes the product NaN even after a zero.
+func Product[TIter SeqX[T], T Number](iterator TIter) T {
+    var zero T
+    if T(1)/T(2) != zero {
+        product := 1.0
+        for v := range iterator {
+            product *= float64(v)
+        }
+        return T(product)
+    }
+
+    signed := zero-1 < zero
+    // the product is tracked as its sign and magnitude, overflowed is set once the magnitude exceeds 64 bits
+    negative, overflowed := false, false
+    magnitude := uint64(1)
+    for v := range iterator {
+        if v == zero {
+            return zero
+        }
+        abs := uint64(v)
+        if signed && v < zero {
+            negative = !negative
+            abs = -uint64(int64(v))
+        }
+        hi, lo := bits.Mul64(magnitude, abs)
+        overflowed = overflowed || hi != 0
+        magnitude = lo
+    }
+    minT, maxT := intLimits[T]()
+    if negative {
+        if overflowed || magnitude > -uint64(minT) {
+            return T(minT)
+        }
+        return T(-int64(magnitude))
+    }
+    if overflowed || magnitude > maxT {
+        return T(maxT)
+    }
+    return T(magnitude)
+}
+
+// intLimits returns the minimum and maximum values of the integer type T.
+func intLimits[T Number]() (int64, uint64) {
+    var zero T
+    width := 64
+    for _, w := range [3]int{8, 16, 32} {
+        // the conversion wraps around to zero if T has exactly w bits
+        if T(uint64(1)<<w) == zero {
+            width = w
+            break
+        }
+    }
+    if zero-1 < zero {
+        return -1 << (width - 1), 1<<(width-1) - 1
+    }
+    return 0, math.MaxUint64 >> (64 - width)
+}
+
+// Mean returns the arithmetic mean of the values provided by the input iterator, or NaN if the input iterator yields nothing.
+// Integers are accumulated in 128 bits, so the sum doesn't overflow even if the values are close to the limit of their type,
+// floating-point numbers are accumulated in float64.
+// For example:
+//
+//  goiter.Mean(goiter.Items[int8](100, 100, 100))   // returns 100, although the sum of the values overflows int8
+func Mean[TIter SeqX[T], T Number](iterator TIter) float64 {
+    count := 0
+    var zero T
+    if T(1)/T(2) != zero {
+        sum := 0.0
+        for v := range iterator {
+            sum += float64(v)
+            count++
+        }
+        if count == 0 {
+            return math.NaN()
+        }
+        return sum / float64(count)
+    }
+
+    signed := zero-1 < zero
+    var sum wideSum
+    for v := range iterator {
+        if signed {
+            sum.addInt(int64(v))
+        } else {
+            sum.addUint(uint64(v))
+        }
+        count++
+    }
+    if count == 0 {
+        return math.NaN()
+    }
+    return sum.float64() / float64(count)
+}
+
+// Summary holds the descriptive statistics computed by Stats function.
+// If Count is 0, the other fields are zero values.
+type Summary[T Number] struct {
+    Count int
+    Min   T
+    Max   T
+    Mean  float64
+    // Variance is the population variance, see SampleVariance method for the sample variance.
+    Variance float64
+}
+
+// StdDev returns the population standard deviation.
+func (s Summary[T]) StdDev() float64 {
+    return math.Sqrt(s.Variance)
+}
+
+// SampleVariance returns the sample variance, which divides by Count-1 instead of Count, or 0 if Count is less than 2.
+func (s Summary[T]) SampleVariance() float64 {
+    if s.Count < 2 {
+        return 0
+    }
+    return s.Variance * float64(s.Count) / float64(s.Count-1)
+}
+
+// Stats consumes the input iterator and computes its count, min, max, mean and variance in a single pass.
+// The mean and the variance are computed with Welford's algorithm, which is numerically stable, and no values are kept in memory.
+// For example:
+//
+//  s := goiter.Stats(goiter.Items(2, 4, 4, 4, 5, 5, 7, 9))
+//  // s.Count is 8, s.Min is 2, s.Max is 9, s.Mean is 5, s.Variance is 4, and s.StdDev() is 2
+func Stats[TIter SeqX[T], T Number](iterator TIter) Summary[T] {
+    var s Summary[T]
+    m2 := 0.0
+    for v := range iterator {
+        s.Count++
+        if s.Count == 1 {
+            s.Min, s.Max = v, v
+        } else {
+            s.Min = min(s.Min, v)
+            s.Max = max(s.Max, v)
+        }
+        x := float64(v)
+        delta := x - s.Mean
+        s.Mean += delta / float64(s.Count)
+        m2 += delta * (x - s.Mean)
+    }
+    if s.Count > 0 {
+        s.Variance = m2 / float64(s.Count)
+    }
+    return s
+}
+
+// wideSum is a 128-bit two's complement integer accumulator.
+type wideSum struct {
+    hi, lo uint64
+}
+
+func (s *wideSum) addInt(x int64) {
+    var carry uint64
+    s.lo, carry = bits.Add64(s.lo, uint64(x), 0)
+    s.hi += uint64(x>>63) + carry
+}
+
+func (s *wideSum) addUint(x uint64) {
+    var carry uint64
+    s.lo, carry = bits.Add64(s.lo, x, 0)
+    s.hi += carry
+}
+
+func (s wideSum) float64() float64 {
+    if int64(s.hi) < 0 {
+        lo, borrow := bits.Sub64(0, s.lo, 0)
+        hi, _ := bits.Sub64(0, s.hi, borrow)
+        return -(float64(hi)*(1<<64) + float64(lo))
+    }
+    return float64(s.hi)*(1<<64) + float64(s.lo)
+}
+
 // Reduce is basically Reduce function in functional programming.
 // The following example uses Reduce to sum up the numbers from 1 to 10:
 //
@@ -66,3 +287,157 @@ func Scan[TIter SeqX[T], TAcc any, T any](
         }
     }
 }
+
+// Accumulate returns an iterator that yields the running sums of the values provided by the input iterator.
+// So if the input iterator yields 1 2 3 4 5, goiter.Accumulate(iterator) will yield 1 3 6 10 15.
+func Accumulate[TIter SeqX[T], T Number](iterator TIter) Iterator[T] {
+    return func(yield func(T) bool) {
+        var sum T
+        for v := range iterator {
+            sum += v
+            if !yield(sum) {
+                return
+            }
+        }
+    }
+}
+
+// AccumulateFunc is like Accumulate, but it uses the provided binary operator instead of addition.
+// The first value of the input iterator is yielded as is, and each subsequent value is combined with the previous result.
+// For example:
+//
+//  iterator := goiter.AccumulateFunc(goiter.Items(3, 1, 4, 1, 5), func(a, b int) int {
+//      return max(a, b)
+//  })  // iterator will yield 3 3 4 4 5
+func AccumulateFunc[TIter SeqX[T], T any](
+    iterator TIter,
+    op func(T, T) T,
+) Iterator[T] {
+    return func(yield func(T) bool) {
+        var acc T
+        first := true
+        for v := range iterator {
+            if first {
+                acc = v
+                first = false
+            } else {
+                acc = op(acc, v)
+            }
+            if !yield(acc) {
+                return
+            }
+        }
+    }
+}
+
+// RunningMax returns an iterator that yields the maximum value seen so far for each value provided by the input iterator.
+// So if the input iterator yields 3 1 4 1 5, goiter.RunningMax(iterator) will yield 3 3 4 4 5.
+func RunningMax[TIter SeqX[T], T cmp.Ordered](iterator TIter) Iterator[T] {
+    return AccumulateFunc(iterator, func(a, b T) T {
+        return max(a, b)
+    })
+}
+
+// RunningMin is like RunningMax, but it yields the minimum value seen so far.
+// So if the input iterator yields 3 1 4 1 5, goiter.RunningMin(iterator) will yield 3 1 1 1 1.
+func RunningMin[TIter SeqX[T], T cmp.Ordered](iterator TIter) Iterator[T] {
+    return AccumulateFunc(iterator, func(a, b T) T {
+        return min(a, b)
+    })
+}
+
+// RunningMaxIndex is like RunningMax, but it also yields the index at which the current maximum value was first seen.
+// So if the input iterator yields 3 1 4 1 5, goiter.RunningMaxIndex(iterator) will yield (0, 3) (0, 3) (2, 4) (2, 4) (4, 5).
+func RunningMaxIndex[TIter SeqX[T], T cmp.Ordered](iterator TIter) Iterator2[int, T] {
+    return runningExtremeIndex(iterator, func(v, curr T) bool {
+        return v > curr
+    })
+}
+
+// RunningMinIndex is like RunningMin, but it also yields the index at which the current minimum value was first seen.
+// So if the input iterator yields 3 1 4 1 5, goiter.RunningMinIndex(iterator) will yield (0, 3) (1, 1) (1, 1) (1, 1) (1, 1).
+func RunningMinIndex[TIter SeqX[T], T cmp.Ordered](iterator TIter) Iterator2[int, T] {
+    return runningExtremeIndex(iterator, func(v, curr T) bool {
+        return v < curr
+    })
+}
+
+func runningExtremeIndex[TIter SeqX[T], T any](iterator TIter, replace func(v, curr T) bool) Iterator2[int, T] {
+    return func(yield func(int, T) bool) {
+        idx := 0
+        extremeIdx := 0
+        var extreme T
+        for v := range iterator {
+            if idx == 0 || replace(v, extreme) {
+                extreme = v
+                extremeIdx = idx
+            }
+            idx++
+            if !yield(extremeIdx, extreme) {
+                return
+            }
+        }
+    }
+}
+
+// CountBy counts the values provided by the input iterator grouped by the key returned by the keySelector function.
+// For example:
+//
+//  counts := goiter.CountBy(goiter.Items("apple", "avocado", "banana"), func(s string) byte {
+//      return s[0]
+//  })  // counts will be map[byte]int{'a': 2, 'b': 1}
+func CountBy[TIter SeqX[T], T any, K comparable](
+    iterator TIter,
+    keySelector func(T) K,
+) map[K]int {
+    result := map[K]int{}
+    for v := range iterator {
+        result[keySelector(v)]++
+    }
+    return result
+}
+
+// Frequencies counts the occurrences of each value provided by the input iterator.
+// So if the input iterator yields "a" "b" "a", goiter.Frequencies(iterator) will return map[string]int{"a": 2, "b": 1}.
+func Frequencies[TIter SeqX[T], T comparable](iterator TIter) map[T]int {
+    return CountBy(iterator, func(v T) T {
+        return v
+    })
+}
+
+// GroupByReduce groups the values provided by the input iterator by the key returned by the keySelector function,
+// and reduces the values of each group with the folder function starting from init, just like Reduce does.
+// It returns an iterator that yields each key with its reduced value, in the order in which the keys first appear.
+// Only one accumulated value per key is kept in memory, the values themselves are not collected.
+// For example:
+//
+//  iterator := goiter.GroupByReduce(goiter.Items("apple", "banana", "avocado"), func(s string) byte {
+//      return s[0]
+//  }, 0, func(acc int, s string) int {
+//      return acc + len(s)
+//  })  // iterator will yield ('a', 12) ('b', 6)
+func GroupByReduce[TIter SeqX[T], T any, K comparable, TAcc any](
+    iterator TIter,
+    keySelector func(T) K,
+    init TAcc,
+    folder func(TAcc, T) TAcc,
+) Iterator2[K, TAcc] {
+    return func(yield func(K, TAcc) bool) {
+        var keys []K
+        accs := map[K]TAcc{}
+        for v := range iterator {
+            k := keySelector(v)
+            acc, ok := accs[k]
+            if !ok {
+                keys = append(keys, k)
+                acc = init
+            }
+            accs[k] = folder(acc, v)
+        }
+        for _, k := range keys {
+            if !yield(k, accs[k]) {
+                return
+            }
+        }
+    }
+}
@@ -2,10 +2,115 @@ package goiter
 
 import (
     "fmt"
+    "maps"
+    "math"
     "slices"
     "testing"
 )
 
+func TestSumProductMean(t *testing.T) {
+    if actual := Sum(Range(1, 10)); actual != 55 {
+        t.Fatal(fmt.Sprintf("expect: 55, actual: %v", actual))
+    }
+    if actual := Sum(Empty[float64]()); actual != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %v", actual))
+    }
+    // integer sums and products saturate instead of wrapping around, the intermediate results never overflow
+    if actual := Sum(Items[int8](100, 100)); actual != 127 {
+        t.Fatal(fmt.Sprintf("expect: 127, actual: %v", actual))
+    }
+    if actual := Sum(Items[int8](-100, -100)); actual != -128 {
+        t.Fatal(fmt.Sprintf("expect: -128, actual: %v", actual))
+    }
+    if actual := Sum(Items[int8](100, 100, -100)); actual != 100 {
+        t.Fatal(fmt.Sprintf("expect: 100, actual: %v", actual))
+    }
+    if actual := Sum(Items[int64](math.MaxInt64, 1, -1)); actual != math.MaxInt64 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", int64(math.MaxInt64), actual))
+    }
+    if actual := Sum(Items[int64](math.MinInt64, -1)); actual != math.MinInt64 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", int64(math.MinInt64), actual))
+    }
+    if actual := Sum(Items[uint8](200, 100)); actual != 255 {
+        t.Fatal(fmt.Sprintf("expect: 255, actual: %v", actual))
+    }
+    if actual := Sum(Items[uint64](math.MaxUint64, 1)); actual != math.MaxUint64 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", uint64(math.MaxUint64), actual))
+    }
+    if actual := Sum(Items[float32](math.MaxFloat32, math.MaxFloat32, -math.MaxFloat32)); actual != math.MaxFloat32 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", float32(math.MaxFloat32), actual))
+    }
+    if actual := Product(Items[int8](16, 16)); actual != 127 {
+        t.Fatal(fmt.Sprintf("expect: 127, actual: %v", actual))
+    }
+    if actual := Product(Items[int8](-16, 16)); actual != -128 {
+        t.Fatal(fmt.Sprintf("expect: -128, actual: %v", actual))
+    }
+    if actual := Product(Items[int8](-16, 8)); actual != -128 {
+        t.Fatal(fmt.Sprintf("expect: -128, actual: %v", actual))
+    }
+    if actual := Product(Items[int8](-2, -3, 7)); actual != 42 {
+        t.Fatal(fmt.Sprintf("expect: 42, actual: %v", actual))
+    }
+    if actual := Product(Items[int64](math.MinInt64, 1)); actual != math.MinInt64 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", int64(math.MinInt64), actual))
+    }
+    if actual := Product(Items[uint64](math.MaxUint64, math.MaxUint64, 2)); actual != math.MaxUint64 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", uint64(math.MaxUint64), actual))
+    }
+    if actual := Product(Concat(Items[int](math.MaxInt64, 2, 0), Counter(1))); actual != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %v", actual))
+    }
+    if actual := Sum(Transform(Items[int8](100, 100), func(v int8) int64 { return int64(v) })); actual != 200 {
+        t.Fatal(fmt.Sprintf("expect: 200, actual: %v", actual))
+    }
+    if actual := Product(Range(1, 5)); actual != 120 {
+        t.Fatal(fmt.Sprintf("expect: 120, actual: %v", actual))
+    }
+    if actual := Product(Empty[int]()); actual != 1 {
+        t.Fatal(fmt.Sprintf("expect: 1, actual: %v", actual))
+    }
+
+    if actual := Mean(Items[int8](100, 100, 100)); actual != 100 {
+        t.Fatal(fmt.Sprintf("expect: 100, actual: %v", actual))
+    }
+    if actual := Mean(Items[int64](math.MinInt64, math.MinInt64)); actual != math.MinInt64 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", float64(math.MinInt64), actual))
+    }
+    if actual := Mean(Items[uint64](math.MaxUint64, math.MaxUint64, 0)); actual != float64(math.MaxUint64)*2/3 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", float64(math.MaxUint64)*2/3, actual))
+    }
+    if actual := Mean(Items(-3, 1)); actual != -1 {
+        t.Fatal(fmt.Sprintf("expect: -1, actual: %v", actual))
+    }
+    if actual := Mean(Items(0.5, 1.5, 4)); actual != 2 {
+        t.Fatal(fmt.Sprintf("expect: 2, actual: %v", actual))
+    }
+    if actual := Mean(Empty[int]()); !math.IsNaN(actual) {
+        t.Fatal(fmt.Sprintf("expect: NaN, actual: %v", actual))
+    }
+}
+
+func TestStats(t *testing.T) {
+    s := Stats(Items(2, 4, 4, 4, 5, 5, 7, 9))
+    if s.Count != 8 || s.Min != 2 || s.Max != 9 || s.Mean != 5 || s.Variance != 4 || s.StdDev() != 2 {
+        t.Fatal(fmt.Sprintf("unexpected summary: %+v", s))
+    }
+    if actual := s.SampleVariance(); actual != 32.0/7 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", 32.0/7, actual))
+    }
+
+    f := Stats(Items(-1.5))
+    if f.Count != 1 || f.Min != -1.5 || f.Max != -1.5 || f.Mean != -1.5 || f.Variance != 0 || f.SampleVariance() != 0 {
+        t.Fatal(fmt.Sprintf("unexpected summary: %+v", f))
+    }
+
+    e := Stats(Empty[int]())
+    if e != (Summary[int]{}) {
+        t.Fatal(fmt.Sprintf("expect zero summary, actual: %+v", e))
+    }
+}
+
 func TestReduce(t *testing.T) {
     foldFunc := func(a int, b int) int {
         return a + b
@@ -77,3 +182,153 @@ func TestCount2(t *testing.T) {
         t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
     }
 }
+
+func TestAccumulate(t *testing.T) {
+    actual := []int{}
+    for each := range Accumulate(Range(1, 5)) {
+        actual = append(actual, each)
+    }
+    expect := []int{1, 3, 6, 10, 15}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actualFloat := []float64{}
+    for each := range Accumulate(Items(0.5, 1.5, 2.0)) {
+        actualFloat = append(actualFloat, each)
+        if each >= 2 {
+            break
+        }
+    }
+    expectFloat := []float64{0.5, 2.0}
+    if !slices.Equal(expectFloat, actualFloat) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expectFloat, actualFloat))
+    }
+}
+
+func TestAccumulateFunc(t *testing.T) {
+    maxFunc := func(a, b int) int {
+        return max(a, b)
+    }
+    actual := []int{}
+    for each := range AccumulateFunc(Items(3, 1, 4, 1, 5), maxFunc) {
+        actual = append(actual, each)
+    }
+    expect := []int{3, 3, 4, 4, 5}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []int{}
+    for each := range AccumulateFunc(Items(2, 3, 4), func(a, b int) int { return a * b }) {
+        actual = append(actual, each)
+        if each == 6 {
+            break
+        }
+    }
+    expect = []int{2, 6}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestRunningMax(t *testing.T) {
+    actual := []int{}
+    for each := range RunningMax(Items(3, 1, 4, 1, 5)) {
+        actual = append(actual, each)
+    }
+    expect := []int{3, 3, 4, 4, 5}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestRunningMin(t *testing.T) {
+    actual := []int{}
+    for each := range RunningMin(Items(3, 1, 4, 1, 5)) {
+        actual = append(actual, each)
+    }
+    expect := []int{3, 1, 1, 1, 1}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestRunningMaxIndex(t *testing.T) {
+    actualIdx := []int{}
+    actual := []int{}
+    for idx, each := range RunningMaxIndex(Items(3, 1, 4, 1, 5)) {
+        actualIdx = append(actualIdx, idx)
+        actual = append(actual, each)
+    }
+    expectIdx := []int{0, 0, 2, 2, 4}
+    expect := []int{3, 3, 4, 4, 5}
+    if !slices.Equal(expectIdx, actualIdx) || !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v %v, actual: %v %v", expectIdx, expect, actualIdx, actual))
+    }
+
+    for _, _ = range RunningMaxIndex(Items(3, 1, 4, 1, 5)) {
+        break
+    }
+}
+
+func TestRunningMinIndex(t *testing.T) {
+    actualIdx := []int{}
+    actual := []int{}
+    for idx, each := range RunningMinIndex(Items(3, 1, 4, 1, 5)) {
+        actualIdx = append(actualIdx, idx)
+        actual = append(actual, each)
+    }
+    expectIdx := []int{0, 1, 1, 1, 1}
+    expect := []int{3, 1, 1, 1, 1}
+    if !slices.Equal(expectIdx, actualIdx) || !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v %v, actual: %v %v", expectIdx, expect, actualIdx, actual))
+    }
+}
+
+func TestCountBy(t *testing.T) {
+    actual := CountBy(Items("apple", "avocado", "banana"), func(s string) byte {
+        return s[0]
+    })
+    expect := map[byte]int{'a': 2, 'b': 1}
+    if !maps.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestFrequencies(t *testing.T) {
+    actual := Frequencies(Items("a", "b", "a", "c", "a"))
+    expect := map[string]int{"a": 3, "b": 1, "c": 1}
+    if !maps.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = Frequencies(Empty[string]())
+    expect = map[string]int{}
+    if !maps.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestGroupByReduce(t *testing.T) {
+    iterator := GroupByReduce(Items("apple", "banana", "avocado", "cherry"), func(s string) byte {
+        return s[0]
+    }, 0, func(acc int, s string) int {
+        return acc + len(s)
+    })
+    actualKeys := []byte{}
+    actual := []int{}
+    for k, v := range iterator {
+        actualKeys = append(actualKeys, k)
+        actual = append(actual, v)
+    }
+    expectKeys := []byte{'a', 'b', 'c'}
+    expect := []int{12, 6, 6}
+    if !slices.Equal(expectKeys, actualKeys) || !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v %v, actual: %v %v", expectKeys, expect, actualKeys, actual))
+    }
+
+    for _, _ = range iterator {
+        break
+    }
+}
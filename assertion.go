@@ -0,0 +1,137 @@
+package goiter
+
+import (
+    "errors"
+    "fmt"
+)
+
+// ErrLimitExceeded is the error that MustFinite panics with (wrapped), when the input iterator yields more values than the limit.
+var ErrLimitExceeded = errors.New("goiter: iterator exceeded the element limit")
+
+// MustFinite returns an iterator that passes through the values of the input iterator,
+// but panics if the input iterator produces more than limit values.
+// It is a safety valve against accidentally ranging over infinite iterators such as Counter without a Take.
+// The panic value is an error wrapping ErrLimitExceeded, so it can be checked with errors.Is after recovering.
+func MustFinite[TIter SeqX[T], T any](iterator TIter, limit int) Iterator[T] {
+    return func(yield func(T) bool) {
+        count := 0
+        for v := range iterator {
+            count++
+            if count > limit {
+                panic(fmt.Errorf("%w: more than %d elements", ErrLimitExceeded, limit))
+            }
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+// MustFinite2 is the iter.Seq2 version of MustFinite function.
+func MustFinite2[TIter Seq2X[T1, T2], T1, T2 any](iterator TIter, limit int) Iterator2[T1, T2] {
+    return func(yield func(T1, T2) bool) {
+        count := 0
+        for v1, v2 := range iterator {
+            count++
+            if count > limit {
+                panic(fmt.Errorf("%w: more than %d elements", ErrLimitExceeded, limit))
+            }
+            if !yield(v1, v2) {
+                return
+            }
+        }
+    }
+}
+
+// AssertMin returns an iterator that passes through the values of the input iterator,
+// and calls the report function with the total number of values if the input iterator yields less than n values.
+// The check is performed when the input iterator is exhausted, so it will not be performed if the consumer breaks out of the loop early.
+// For example:
+//
+//  iterator := goiter.AssertMin(goiter.Items(1, 2), 3, func(count int) {
+//      log.Printf("expect at least 3 records, got %d", count)
+//  })
+func AssertMin[TIter SeqX[T], T any](iterator TIter, n int, report func(count int)) Iterator[T] {
+    return assertLen(iterator, func(count int) bool { return count >= n }, report)
+}
+
+// AssertMax is like AssertMin, but it reports if the input iterator yields more than n values.
+func AssertMax[TIter SeqX[T], T any](iterator TIter, n int, report func(count int)) Iterator[T] {
+    return assertLen(iterator, func(count int) bool { return count <= n }, report)
+}
+
+// AssertLen is like AssertMin, but it reports if the input iterator does not yield exactly n values.
+func AssertLen[TIter SeqX[T], T any](iterator TIter, n int, report func(count int)) Iterator[T] {
+    return assertLen(iterator, func(count int) bool { return count == n }, report)
+}
+
+func assertLen[TIter SeqX[T], T any](iterator TIter, valid func(count int) bool, report func(count int)) Iterator[T] {
+    return func(yield func(T) bool) {
+        count := 0
+        for v := range iterator {
+            count++
+            if !yield(v) {
+                return
+            }
+        }
+        if !valid(count) {
+            report(count)
+        }
+    }
+}
+
+// ErrAssertionFailed is the error that Assert panics with (wrapped), and AssertE yields (wrapped), when a value violates the invariant.
+var ErrAssertionFailed = errors.New("goiter: assertion failed")
+
+// Assert returns an iterator that passes through the values of the input iterator, but panics if a value doesn't satisfy the predicate.
+// It is meant to catch the contract violations between the stages of a pipeline during development and in tests.
+// The panic value is an error wrapping ErrAssertionFailed, with the message returned by msg, if msg is nil, the value itself is printed.
+// For example:
+//
+//  iterator := goiter.Assert(prices, func(p float64) bool {
+//      return p >= 0
+//  }, func(p float64) string {
+//      return fmt.Sprintf("negative price %v", p)
+//  })
+func Assert[TIter SeqX[T], T any](iterator TIter, predicate func(T) bool, msg func(T) string) Iterator[T] {
+    return func(yield func(T) bool) {
+        idx := 0
+        for v := range iterator {
+            if !predicate(v) {
+                panic(assertionError(idx, v, msg))
+            }
+            if !yield(v) {
+                return
+            }
+            idx++
+        }
+    }
+}
+
+// AssertE is like Assert, but instead of panicking, it yields the error as the last element and stops.
+func AssertE[TIter SeqX[T], T any](iterator TIter, predicate func(T) bool, msg func(T) string) IteratorE[T] {
+    return func(yield func(T, error) bool) {
+        idx := 0
+        for v := range iterator {
+            if !predicate(v) {
+                var zero T
+                yield(zero, assertionError(idx, v, msg))
+                return
+            }
+            if !yield(v, nil) {
+                return
+            }
+            idx++
+        }
+    }
+}
+
+func assertionError[T any](idx int, v T, msg func(T) string) error {
+    var message string
+    if msg != nil {
+        message = msg(v)
+    } else {
+        message = fmt.Sprintf("unexpected value %v", v)
+    }
+    return fmt.Errorf("%w: element %d: %s", ErrAssertionFailed, idx, message)
+}
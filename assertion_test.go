@@ -0,0 +1,169 @@
+package goiter
+
+import (
+    "errors"
+    "fmt"
+    "slices"
+    "strings"
+    "testing"
+)
+
+func TestMustFinite(t *testing.T) {
+    actual := []int{}
+    for each := range MustFinite(Range(1, 3), 3) {
+        actual = append(actual, each)
+    }
+    expect := []int{1, 2, 3}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _ = range MustFinite(Counter(0), 3) {
+        break
+    }
+
+    defer func() {
+        r := recover()
+        err, ok := r.(error)
+        if !ok || !errors.Is(err, ErrLimitExceeded) {
+            t.Fatal(fmt.Sprintf("expect panic with ErrLimitExceeded, actual: %v", r))
+        }
+    }()
+    for _ = range MustFinite(Counter(0), 3) {
+    }
+}
+
+func TestMustFinite2(t *testing.T) {
+    actual := []int{}
+    for idx := range MustFinite2(Slice([]int{1, 2, 3}), 3) {
+        actual = append(actual, idx)
+    }
+    expect := []int{0, 1, 2}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _, _ = range MustFinite2(Slice([]int{1, 2, 3}), 1) {
+        break
+    }
+
+    defer func() {
+        r := recover()
+        err, ok := r.(error)
+        if !ok || !errors.Is(err, ErrLimitExceeded) {
+            t.Fatal(fmt.Sprintf("expect panic with ErrLimitExceeded, actual: %v", r))
+        }
+    }()
+    for _, _ = range MustFinite2(Slice([]int{1, 2, 3}), 2) {
+    }
+}
+
+func TestAssertMin(t *testing.T) {
+    reported := -1
+    report := func(count int) { reported = count }
+
+    actual := []int{}
+    for each := range AssertMin(Items(1, 2), 3, report) {
+        actual = append(actual, each)
+    }
+    expect := []int{1, 2}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+    if reported != 2 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", 2, reported))
+    }
+
+    reported = -1
+    for _ = range AssertMin(Items(1, 2, 3), 3, report) {
+    }
+    if reported != -1 {
+        t.Fatal(fmt.Sprintf("expect no report, actual: %v", reported))
+    }
+
+    // breaking out early doesn't report
+    for _ = range AssertMin(Items(1, 2), 3, report) {
+        break
+    }
+    if reported != -1 {
+        t.Fatal(fmt.Sprintf("expect no report, actual: %v", reported))
+    }
+}
+
+func TestAssertMax(t *testing.T) {
+    reported := -1
+    report := func(count int) { reported = count }
+
+    for _ = range AssertMax(Items(1, 2, 3), 2, report) {
+    }
+    if reported != 3 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", 3, reported))
+    }
+
+    reported = -1
+    for _ = range AssertMax(Items(1, 2), 2, report) {
+    }
+    if reported != -1 {
+        t.Fatal(fmt.Sprintf("expect no report, actual: %v", reported))
+    }
+}
+
+func TestAssertLen(t *testing.T) {
+    reported := -1
+    report := func(count int) { reported = count }
+
+    for _ = range AssertLen(Items(1, 2, 3), 2, report) {
+    }
+    if reported != 3 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", 3, reported))
+    }
+
+    reported = -1
+    for _ = range AssertLen(Items(1), 2, report) {
+    }
+    if reported != 1 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", 1, reported))
+    }
+
+    reported = -1
+    for _ = range AssertLen(Items(1, 2), 2, report) {
+    }
+    if reported != -1 {
+        t.Fatal(fmt.Sprintf("expect no report, actual: %v", reported))
+    }
+}
+
+func TestAssert(t *testing.T) {
+    positive := func(v int) bool { return v > 0 }
+    actual := Assert(Range(1, 3), positive, nil).ToSlice()
+    if !slices.Equal([]int{1, 2, 3}, actual) {
+        t.Fatal(fmt.Sprintf("expect [1 2 3], actual: %v", actual))
+    }
+
+    for _ = range Assert(Range(1, 3), positive, nil) {
+        break
+    }
+
+    defer func() {
+        r := recover()
+        err, ok := r.(error)
+        if !ok || !errors.Is(err, ErrAssertionFailed) || !strings.Contains(err.Error(), "element 2: got -1") {
+            t.Fatal(fmt.Sprintf("expect panic with ErrAssertionFailed, actual: %v", r))
+        }
+    }()
+    for _ = range Assert(Items(1, 2, -1), positive, func(v int) string { return fmt.Sprintf("got %d", v) }) {
+    }
+}
+
+func TestAssertE(t *testing.T) {
+    positive := func(v int) bool { return v > 0 }
+    values, err := CollectE(AssertE(Items(1, 0, 2), positive, nil))
+    if !slices.Equal([]int{1}, values) || !errors.Is(err, ErrAssertionFailed) || !strings.Contains(err.Error(), "unexpected value 0") {
+        t.Fatal(fmt.Sprintf("expect [1] and ErrAssertionFailed, actual: %v, %v", values, err))
+    }
+
+    values, err = CollectE(AssertE(Items(1, 2), positive, nil))
+    if !slices.Equal([]int{1, 2}, values) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [1 2] and no error, actual: %v, %v", values, err))
+    }
+}
@@ -0,0 +1,144 @@
+package goiter
+
+import (
+    "errors"
+    "fmt"
+    "iter"
+    "os"
+)
+
+// ErrMemoryBudgetExceeded is the error reported by the buffering operators configured by WithMemoryBudget,
+// when the values they retain exceed the budget and spilling is not configured.
+var ErrMemoryBudgetExceeded = errors.New("goiter: iterator exceeded the memory budget")
+
+// BufferOption configures the operators that buffer the values of the input iterator:
+// Reverse, OrderBy, StableOrderBy, Cache, TakeLast, their iter.Seq2 versions Reverse2, Order2By, StableOrder2By, Cache2, TakeLast2,
+// and the corresponding methods of Iterator and Iterator2. T is the element type of the iterator. The iter.Seq2 versions buffer the 2-tuples
+// as *Combined[T1, T2], so they take options of that element type, the same as the comparison function of Order2By.
+// Order, Order2V1 and Order2V2 don't accept options, use OrderBy or Order2By with cmp.Compare instead.
+type BufferOption[T any] func(*bufferBudget[T])
+
+// WithMemoryBudget limits the memory retained by a buffering operator to bytes, the size of each value is computed by sizeOf.
+// Only the values the operator still holds are counted, for example, TakeLast(it, n) holds at most n values no matter how long it is.
+// When the retained values exceed the budget, the operator spills them to disk if WithSpill is also given,
+// otherwise it stops the iteration and stores an error wrapping ErrMemoryBudgetExceeded into *errp.
+// The errors of spilling are stored into *errp as well. *errp is set to nil when the iteration starts,
+// except for Cache, where each iteration stores its result when it ends, so after an iteration *errp holds the error of that iteration.
+// If errp is nil, the operator panics with the error instead.
+// For example:
+//
+//  var err error
+//  iterator := goiter.OrderBy(lines, strings.Compare, goiter.WithMemoryBudget(64<<20, func(s string) int { return len(s) }, &err))
+//  for line := range iterator {
+//      ...
+//  }
+//  if errors.Is(err, goiter.ErrMemoryBudgetExceeded) {
+//      ...
+//  }
+func WithMemoryBudget[T any](bytes int, sizeOf func(T) int, errp *error) BufferOption[T] {
+    return func(b *bufferBudget[T]) {
+        b.bytes = bytes
+        b.sizeOf = sizeOf
+        b.errp = errp
+    }
+}
+
+// WithSpill makes a buffering operator write its buffer to temporary files in dir with codec when the budget set by WithMemoryBudget
+// is exceeded, instead of stopping the iteration. It has no effect without WithMemoryBudget.
+// Reverse and OrderBy read the files back when yielding, and remove them when the iteration ends.
+// Cache keeps all the values in one file for replaying, which is removed when the cached iterator is garbage collected.
+// TakeLast doesn't spill, since the values it holds are needed at once, so it always stops when the budget is exceeded.
+func WithSpill[T any](codec Codec[T], dir string) BufferOption[T] {
+    return func(b *bufferBudget[T]) {
+        b.codec = codec
+        b.dir = dir
+    }
+}
+
+// bufferBudget is the resolved WithMemoryBudget and WithSpill options of a buffering operator.
+type bufferBudget[T any] struct {
+    bytes  int
+    sizeOf func(T) int
+    errp   *error
+    // codec is nil if spilling is not configured.
+    codec Codec[T]
+    dir   string
+}
+
+// newBufferBudget returns nil if no budget is configured.
+func newBufferBudget[T any](opts []BufferOption[T]) *bufferBudget[T] {
+    b := &bufferBudget[T]{}
+    for _, opt := range opts {
+        opt(b)
+    }
+    if b.sizeOf == nil {
+        return nil
+    }
+    return b
+}
+
+func (b *bufferBudget[T]) start() {
+    if b.errp != nil {
+        *b.errp = nil
+    }
+}
+
+// fail stores err into *errp, or panics with it if errp is nil, since the error would be lost otherwise.
+func (b *bufferBudget[T]) fail(err error) {
+    if b.errp == nil {
+        panic(err)
+    }
+    *b.errp = err
+}
+
+// report stores the result of an iteration, err may be nil.
+func (b *bufferBudget[T]) report(err error) {
+    if err != nil {
+        b.fail(err)
+        return
+    }
+    b.start()
+}
+
+func (b *bufferBudget[T]) exceeded() error {
+    return fmt.Errorf("%w: more than %d bytes", ErrMemoryBudgetExceeded, b.bytes)
+}
+
+// collect buffers all the values of iterator within the budget. If spilling is configured, the buffer is spilled as a run
+// whenever it exceeds the budget, and the runs are sorted by cmp unless cmp is nil, otherwise it fails when the budget is exceeded.
+// It returns the names of the runs in the spilling order and the values left in the buffer.
+// The runs should be removed by the caller even if an error is returned.
+func (b *bufferBudget[T]) collect(iterator iter.Seq[T], cmp func(T, T) int) ([]string, []T, error) {
+    used := 0
+    var runs []string
+    var buffer []T
+    for v := range iterator {
+        used += b.sizeOf(v)
+        if used <= b.bytes {
+            buffer = append(buffer, v)
+            continue
+        }
+        if b.codec == nil {
+            return runs, nil, b.exceeded()
+        }
+
+        buffer = append(buffer, v)
+        name, err := spillRun(buffer, cmp, b.codec, b.dir)
+        if name != "" {
+            runs = append(runs, name)
+        }
+        if err != nil {
+            return runs, nil, err
+        }
+        clear(buffer)
+        buffer = buffer[:0]
+        used = 0
+    }
+    return runs, buffer, nil
+}
+
+func removeFiles(names []string) {
+    for _, name := range names {
+        _ = os.Remove(name)
+    }
+}
@@ -0,0 +1,230 @@
+package goiter
+
+import (
+    "cmp"
+    "errors"
+    "fmt"
+    "math/rand"
+    "os"
+    "runtime"
+    "slices"
+    "testing"
+    "time"
+)
+
+func unitSize(int) int {
+    return 1
+}
+
+func TestWithMemoryBudget_Reverse(t *testing.T) {
+    var err error
+    actual := Reverse(Range(1, 5), WithMemoryBudget(5, unitSize, &err)).ToSlice()
+    if !slices.Equal([]int{5, 4, 3, 2, 1}, actual) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [5 4 3 2 1] without error, actual: %v with error %v", actual, err))
+    }
+
+    actual = Reverse(Range(1, 6), WithMemoryBudget(5, unitSize, &err)).ToSlice()
+    if len(actual) != 0 || !errors.Is(err, ErrMemoryBudgetExceeded) {
+        t.Fatal(fmt.Sprintf("expect nothing with ErrMemoryBudgetExceeded, actual: %v with error %v", actual, err))
+    }
+
+    dir := t.TempDir()
+    actual = Range(1, 10).Reverse(WithMemoryBudget(3, unitSize, &err), WithSpill(GobCodec[int](), dir)).ToSlice()
+    if !slices.Equal([]int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, actual) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [10 9 8 7 6 5 4 3 2 1] without error, actual: %v with error %v", actual, err))
+    }
+    if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+        t.Fatal(fmt.Sprintf("expect the spilled runs to be removed, actual: %d files", len(entries)))
+    }
+}
+
+func TestWithMemoryBudget_OrderBy(t *testing.T) {
+    r := rand.New(rand.NewSource(1))
+    input := make([]int, 100)
+    for i := range input {
+        input[i] = r.Intn(50)
+    }
+    expect := slices.Sorted(slices.Values(input))
+
+    var err error
+    actual := OrderBy(SliceElems(input), cmp.Compare[int], WithMemoryBudget(10, unitSize, &err)).ToSlice()
+    if len(actual) != 0 || !errors.Is(err, ErrMemoryBudgetExceeded) {
+        t.Fatal(fmt.Sprintf("expect nothing with ErrMemoryBudgetExceeded, actual: %v with error %v", actual, err))
+    }
+
+    dir := t.TempDir()
+    actual = OrderBy(SliceElems(input), cmp.Compare[int], WithMemoryBudget(10, unitSize, &err), WithSpill(GobCodec[int](), dir)).ToSlice()
+    if !slices.Equal(expect, actual) || err != nil {
+        t.Fatal(fmt.Sprintf("expect: %v without error, actual: %v with error %v", expect, actual, err))
+    }
+    if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+        t.Fatal(fmt.Sprintf("expect the spilled runs to be removed, actual: %d files", len(entries)))
+    }
+
+    type record struct {
+        Key int
+        Seq int
+    }
+    records := make([]record, 50)
+    for i := range records {
+        records[i] = record{Key: r.Intn(5), Seq: i}
+    }
+    byKey := func(a, b record) int {
+        return cmp.Compare(a.Key, b.Key)
+    }
+    sorted := SliceElems(records).StableOrderBy(byKey, WithMemoryBudget(7, func(record) int { return 1 }, &err), WithSpill(JSONCodec[record](), dir)).ToSlice()
+    if !slices.IsSortedFunc(sorted, func(a, b record) int {
+        if c := byKey(a, b); c != 0 {
+            return c
+        }
+        return cmp.Compare(a.Seq, b.Seq)
+    }) || len(sorted) != 50 || err != nil {
+        t.Fatal(fmt.Sprintf("expect a stable order without error, actual: %v with error %v", sorted, err))
+    }
+}
+
+func TestWithMemoryBudget_TakeLast(t *testing.T) {
+    // only the last n values are retained, so a long input fits in a small budget
+    var err error
+    actual := TakeLast(Range(1, 100), 3, WithMemoryBudget(3, unitSize, &err)).ToSlice()
+    if !slices.Equal([]int{98, 99, 100}, actual) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [98 99 100] without error, actual: %v with error %v", actual, err))
+    }
+    actual = Range(1, 2).TakeLast(3, WithMemoryBudget(3, unitSize, &err)).ToSlice()
+    if !slices.Equal([]int{1, 2}, actual) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [1 2] without error, actual: %v with error %v", actual, err))
+    }
+
+    sizeOf := func(s string) int { return len(s) }
+    actual2 := TakeLast(Items("aaaa", "b", "c", "dddd"), 2, WithMemoryBudget(4, sizeOf, &err)).ToSlice()
+    if len(actual2) != 0 || !errors.Is(err, ErrMemoryBudgetExceeded) {
+        t.Fatal(fmt.Sprintf("expect nothing with ErrMemoryBudgetExceeded, actual: %v with error %v", actual2, err))
+    }
+}
+
+func TestWithMemoryBudget_Cache(t *testing.T) {
+    count := 0
+    source := func(n int) Iterator[int] {
+        return Range(1, n).Through(func(v int) int {
+            count++
+            return v
+        })
+    }
+
+    var err error
+    iterator := source(3).Cache(WithMemoryBudget(3, unitSize, &err))
+    for i := 0; i < 2; i++ {
+        actual := iterator.ToSlice()
+        if !slices.Equal([]int{1, 2, 3}, actual) || count != 3 || err != nil {
+            t.Fatal(fmt.Sprintf("expect [1 2 3] with 3 computations, actual: %v with %d computations and error %v", actual, count, err))
+        }
+    }
+
+    count = 0
+    iterator = Cache(source(5), WithMemoryBudget(3, unitSize, &err))
+    actual := iterator.ToSlice()
+    if !slices.Equal([]int{1, 2, 3}, actual) || !errors.Is(err, ErrMemoryBudgetExceeded) {
+        t.Fatal(fmt.Sprintf("expect [1 2 3] with ErrMemoryBudgetExceeded, actual: %v with error %v", actual, err))
+    }
+    // nothing is cached, so the input iterator is iterated again
+    _ = iterator.ToSlice()
+    if count != 8 {
+        t.Fatal(fmt.Sprintf("expect: 8, actual: %d", count))
+    }
+
+    count = 0
+    dir := t.TempDir()
+    iterator = Cache(source(5), WithMemoryBudget(3, unitSize, &err), WithSpill(GobCodec[int](), dir))
+    for i := 0; i < 2; i++ {
+        actual = iterator.ToSlice()
+        if !slices.Equal([]int{1, 2, 3, 4, 5}, actual) || count != 5 || err != nil {
+            t.Fatal(fmt.Sprintf("expect [1 2 3 4 5] with 5 computations, actual: %v with %d computations and error %v", actual, count, err))
+        }
+    }
+    if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+        t.Fatal(fmt.Sprintf("expect 1 spill file, actual: %d files", len(entries)))
+    }
+
+    // an incomplete iteration removes its spill file
+    dir = t.TempDir()
+    iterator = Cache(source(5), WithMemoryBudget(3, unitSize, &err), WithSpill(GobCodec[int](), dir))
+    _ = iterator.Take(4).ToSlice()
+    if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+        t.Fatal(fmt.Sprintf("expect no spill file, actual: %d files", len(entries)))
+    }
+}
+
+func TestWithMemoryBudget_NilErrp(t *testing.T) {
+    defer func() {
+        if r := recover(); r == nil || !errors.Is(r.(error), ErrMemoryBudgetExceeded) {
+            t.Fatal(fmt.Sprintf("expect a panic with ErrMemoryBudgetExceeded, actual: %v", r))
+        }
+    }()
+    // within the budget, nothing is reported
+    _ = Reverse(Range(1, 3), WithMemoryBudget(3, unitSize, nil)).ToSlice()
+    _ = Reverse(Range(1, 4), WithMemoryBudget(3, unitSize, nil)).ToSlice()
+}
+
+func TestWithMemoryBudget_Seq2(t *testing.T) {
+    pairSize := func(*Combined[string, int]) int { return 1 }
+    input := Slice([]string{"c", "a", "b"}).Swap()
+    var err error
+
+    keys, values := Reverse2(input, WithMemoryBudget(2, pairSize, &err), WithSpill(GobCodec[*Combined[string, int]](), t.TempDir())).ToSlices()
+    if !slices.Equal([]string{"b", "a", "c"}, keys) || !slices.Equal([]int{2, 1, 0}, values) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [b a c] [2 1 0] without error, actual: %v %v with error %v", keys, values, err))
+    }
+
+    byKey := func(a, b *Combined[string, int]) int {
+        return cmp.Compare(a.V1, b.V1)
+    }
+    keys = input.OrderBy(byKey, WithMemoryBudget(2, pairSize, &err), WithSpill(JSONCodec[*Combined[string, int]](), t.TempDir())).PickV1().ToSlice()
+    if !slices.Equal([]string{"a", "b", "c"}, keys) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [a b c] without error, actual: %v with error %v", keys, err))
+    }
+
+    keys = input.TakeLast(2, WithMemoryBudget(2, pairSize, &err)).PickV1().ToSlice()
+    if !slices.Equal([]string{"a", "b"}, keys) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [a b] without error, actual: %v with error %v", keys, err))
+    }
+
+    keys = Cache2(input, WithMemoryBudget(2, pairSize, &err)).PickV1().ToSlice()
+    if !slices.Equal([]string{"c", "a"}, keys) || !errors.Is(err, ErrMemoryBudgetExceeded) {
+        t.Fatal(fmt.Sprintf("expect [c a] with ErrMemoryBudgetExceeded, actual: %v with error %v", keys, err))
+    }
+}
+
+func TestWithMemoryBudget_CacheCleanup(t *testing.T) {
+    dir := t.TempDir()
+    var err error
+    iterator := Cache(Range(1, 5), WithMemoryBudget(3, unitSize, &err), WithSpill(GobCodec[int](), dir))
+    _ = iterator.ToSlice()
+    entries, _ := os.ReadDir(dir)
+    if len(entries) != 1 {
+        t.Fatal(fmt.Sprintf("expect 1 spill file, actual: %d files", len(entries)))
+    }
+
+    // a failed replay is reported by that iteration, and a later successful iteration clears it
+    name := dir + "/" + entries[0].Name()
+    data, _ := os.ReadFile(name)
+    _ = os.Remove(name)
+    _ = iterator.ToSlice()
+    if err == nil {
+        t.Fatal("expect an error when the spill file is missing")
+    }
+    _ = os.WriteFile(name, data, 0o600)
+    if actual := iterator.ToSlice(); !slices.Equal([]int{1, 2, 3, 4, 5}, actual) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [1 2 3 4 5] without error, actual: %v with error %v", actual, err))
+    }
+
+    // the spill file is removed once the iterator is unreachable
+    iterator = nil
+    for i := 0; i < 50; i++ {
+        runtime.GC()
+        if entries, _ := os.ReadDir(dir); len(entries) == 0 {
+            return
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    t.Fatal("expect the spill file to be removed")
+}
@@ -0,0 +1,226 @@
+package goiter
+
+import (
+    "bufio"
+    "hash"
+    "io"
+    "math/bits"
+)
+
+// SliceChunks returns an iterator that yields consecutive subslices of s, each of length size, except the last one which may be shorter.
+// The subslices share the backing array of s, so no data is copied, and modifying the elements of a chunk modifies s as well.
+// The capacity of each chunk is limited to its length, so appending to a chunk never overwrites the next chunk.
+// For example:
+//
+//  goiter.SliceChunks([]int{1, 2, 3, 4, 5}, 2)    // yields [1 2] [3 4] [5]
+//
+// If size is less than or equal to 0, it yields nothing.
+func SliceChunks[S ~[]T, T any](s S, size int) Iterator[S] {
+    if size <= 0 {
+        return Empty[S]()
+    }
+
+    return func(yield func(S) bool) {
+        for i := 0; i < len(s); i += size {
+            end := min(i+size, len(s))
+            if !yield(s[i:end:end]) {
+                return
+            }
+        }
+    }
+}
+
+// WindowStep returns an iterator that yields the windows of size consecutive values of the input iterator,
+// each window starts step values after the previous one, so the windows overlap if step is less than size,
+// and some values are skipped if step is greater than size. Only full windows are yielded.
+// Each yielded window is a newly allocated slice, so it is safe to keep it.
+// For example:
+//
+//  goiter.WindowStep(goiter.Range(1, 7), 3, 2)    // yields [1 2 3] [3 4 5] [5 6 7]
+//  goiter.WindowStep(goiter.Range(1, 7), 2, 3)    // yields [1 2] [4 5]
+//
+// If size or step is less than or equal to 0, it yields nothing.
+func WindowStep[TIter SeqX[T], T any](iterator TIter, size, step int) Iterator[[]T] {
+    if size <= 0 || step <= 0 {
+        return Empty[[]T]()
+    }
+
+    return func(yield func([]T) bool) {
+        buffer := make([]T, 0, size)
+        skip := 0
+        for v := range iterator {
+            if skip > 0 {
+                skip--
+                continue
+            }
+            buffer = append(buffer, v)
+            if len(buffer) < size {
+                continue
+            }
+            if !yield(append(make([]T, 0, size), buffer...)) {
+                return
+            }
+            if step >= size {
+                buffer = buffer[:0]
+                skip = step - size
+            } else {
+                buffer = append(buffer[:0], buffer[step:]...)
+            }
+        }
+    }
+}
+
+// CDCChunks returns an iterator that splits the data read from r into content-defined chunks.
+// The chunk boundaries are determined by a gear-based rolling hash over the content instead of fixed offsets,
+// so inserting or removing bytes only affects the chunks around the modification, and the rest of the chunks stay the same.
+// This makes it a building block for deduplication and backup tools.
+//
+// The avgSize parameter is the expected average size of the chunks, every chunk except the last one has a size
+// between avgSize/4 and avgSize*4. Each yielded chunk is a newly allocated slice, so it is safe to keep it.
+// If avgSize is less than or equal to 0, it yields nothing.
+//
+// The iteration stops when r returns io.EOF, the remaining data is yielded as the last chunk.
+// Each chunk is yielded with a nil error. If r returns any other error, a nil chunk and the error are yielded and the iteration stops,
+// the partially read chunk is discarded, so a truncated chunk is never yielded as if it were complete.
+// For example:
+//
+//  for chunk, err := range goiter.CDCChunks(file, 8192) {
+//      if err != nil {
+//          return err
+//      }
+//      store.Put(sha256.Sum256(chunk), chunk)
+//  }
+func CDCChunks(r io.Reader, avgSize int) IteratorE[[]byte] {
+    if avgSize <= 0 {
+        return func(yield func([]byte, error) bool) {}
+    }
+
+    minSize := avgSize / 4
+    maxSize := avgSize * 4
+    maskBits := bits.Len(uint(avgSize)) - 1
+
+    return func(yield func([]byte, error) bool) {
+        br := bufio.NewReader(r)
+        chunk := make([]byte, 0, avgSize)
+        var hash uint64
+        for {
+            b, err := br.ReadByte()
+            if err != nil {
+                if err != io.EOF {
+                    yield(nil, err)
+                    return
+                }
+                if len(chunk) > 0 {
+                    yield(chunk, nil)
+                }
+                return
+            }
+
+            chunk = append(chunk, b)
+            hash = (hash << 1) + gearTable[b]
+            // the highest bits of the hash are affected by the most recent 64 bytes
+            if (len(chunk) >= minSize && hash>>(64-maskBits) == 0) || len(chunk) >= maxSize {
+                if !yield(chunk, nil) {
+                    return
+                }
+                chunk = make([]byte, 0, avgSize)
+                hash = 0
+            }
+        }
+    }
+}
+
+// HashChunks returns an iterator that yields each chunk provided by the input iterator along with its digest,
+// the digest is computed by a hash.Hash created by the newHash function, for example sha256.New.
+// For example:
+//
+//  for chunk, digest := range goiter.HashChunks(goiter.SliceChunks(data, 8192), sha256.New) {
+//      fmt.Printf("%x %d\n", digest, len(chunk))
+//  }
+func HashChunks[TIter SeqX[[]byte]](iterator TIter, newHash func() hash.Hash) Iterator2[[]byte, []byte] {
+    return func(yield func([]byte, []byte) bool) {
+        h := newHash()
+        for chunk := range iterator {
+            h.Reset()
+            h.Write(chunk)
+            if !yield(chunk, h.Sum(nil)) {
+                return
+            }
+        }
+    }
+}
+
+// MerkleRoot consumes the (chunk, digest) pairs provided by the input iterator, usually created by HashChunks,
+// and returns the root hash of the Merkle tree built upon the digests.
+// Like RFC 6962, the leaves and the internal nodes are hashed in different domains to prevent second-preimage attacks:
+// each leaf is the hash of 0x00 followed by the digest, and each parent node is the hash of 0x01 followed by its two children.
+// A node without sibling is promoted to the upper level as is.
+// It only keeps O(log n) digests in memory, so it can be used on long streams.
+// If the input iterator yields nothing, it returns the digest of empty data.
+func MerkleRoot[TIter Seq2X[[]byte, []byte]](iterator TIter, newHash func() hash.Hash) []byte {
+    h := newHash()
+    hashLeaf := func(digest []byte) []byte {
+        h.Reset()
+        h.Write([]byte{0x00})
+        h.Write(digest)
+        return h.Sum(nil)
+    }
+    hashPair := func(left, right []byte) []byte {
+        h.Reset()
+        h.Write([]byte{0x01})
+        h.Write(left)
+        h.Write(right)
+        return h.Sum(nil)
+    }
+
+    // levels[i] is the pending node at height i, or nil if there is none
+    var levels [][]byte
+    for _, digest := range iterator {
+        node := hashLeaf(digest)
+        for height := 0; ; height++ {
+            if height == len(levels) {
+                levels = append(levels, node)
+                break
+            }
+            if levels[height] == nil {
+                levels[height] = node
+                break
+            }
+            node = hashPair(levels[height], node)
+            levels[height] = nil
+        }
+    }
+
+    var root []byte
+    for _, node := range levels {
+        if node == nil {
+            continue
+        }
+        if root == nil {
+            root = node
+        } else {
+            root = hashPair(node, root)
+        }
+    }
+    if root == nil {
+        h.Reset()
+        return h.Sum(nil)
+    }
+    return root
+}
+
+var gearTable = newGearTable()
+
+func newGearTable() [256]uint64 {
+    // splitmix64 with a fixed seed, so the chunk boundaries are stable across runs and versions
+    var table [256]uint64
+    seed := uint64(0x9E3779B97F4A7C15)
+    for i := range table {
+        seed += 0x9E3779B97F4A7C15
+        z := seed
+        z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+        z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+        table[i] = z ^ (z >> 31)
+    }
+    return table
+}
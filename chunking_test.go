@@ -0,0 +1,193 @@
+package goiter
+
+import (
+    "bytes"
+    "crypto/sha256"
+    "errors"
+    "fmt"
+    "io"
+    "math/rand"
+    "slices"
+    "testing"
+    "testing/iotest"
+)
+
+func TestSliceChunks(t *testing.T) {
+    s := []int{1, 2, 3, 4, 5}
+    chunks := SliceChunks(s, 2).ToSlice()
+    if len(chunks) != 3 || !slices.Equal([]int{1, 2}, chunks[0]) || !slices.Equal([]int{3, 4}, chunks[1]) || !slices.Equal([]int{5}, chunks[2]) {
+        t.Fatal(fmt.Sprintf("expect: [[1 2] [3 4] [5]], actual: %v", chunks))
+    }
+
+    // chunks share the backing array, but appending doesn't affect the next chunk
+    chunks[0][0] = 10
+    _ = append(chunks[0], 100)
+    if !slices.Equal([]int{10, 2, 3, 4, 5}, s) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{10, 2, 3, 4, 5}, s))
+    }
+
+    if n := Count(SliceChunks(s, 0)); n != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", n))
+    }
+    if n := Count(SliceChunks([]int{}, 3)); n != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", n))
+    }
+    if n := Count(SliceChunks(s, 1).Take(2)); n != 2 {
+        t.Fatal(fmt.Sprintf("expect: 2, actual: %d", n))
+    }
+}
+
+func TestWindowStep(t *testing.T) {
+    windows := WindowStep(Range(1, 7), 3, 2).ToSlice()
+    expect := [][]int{{1, 2, 3}, {3, 4, 5}, {5, 6, 7}}
+    if !slices.EqualFunc(expect, windows, slices.Equal[[]int]) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, windows))
+    }
+
+    windows = WindowStep(Range(1, 7), 2, 3).ToSlice()
+    expect = [][]int{{1, 2}, {4, 5}}
+    if !slices.EqualFunc(expect, windows, slices.Equal[[]int]) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, windows))
+    }
+
+    windows = WindowStep(Range(1, 4), 2, 2).ToSlice()
+    expect = [][]int{{1, 2}, {3, 4}}
+    if !slices.EqualFunc(expect, windows, slices.Equal[[]int]) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, windows))
+    }
+
+    windows = WindowStep(Range(1, 5), 1, 1).Take(2).ToSlice()
+    expect = [][]int{{1}, {2}}
+    if !slices.EqualFunc(expect, windows, slices.Equal[[]int]) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, windows))
+    }
+
+    if n := Count(WindowStep(Range(1, 2), 3, 1)); n != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", n))
+    }
+    if n := Count(WindowStep(Range(1, 5), 2, 0)); n != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", n))
+    }
+}
+
+func TestCDCChunks(t *testing.T) {
+    data := make([]byte, 64*1024)
+    rand.New(rand.NewSource(1)).Read(data)
+
+    // case 1: chunks make up the original data and respect the size bounds
+    chunks, err := CDCChunks(bytes.NewReader(data), 1024).Collect()
+    if !bytes.Equal(data, bytes.Join(chunks, nil)) || err != nil {
+        t.Fatal(fmt.Sprintf("expect chunks to make up the original data without error, actual error: %v", err))
+    }
+    for i, chunk := range chunks {
+        if len(chunk) > 4096 || (i < len(chunks)-1 && len(chunk) < 256) {
+            t.Fatal(fmt.Sprintf("unexpected chunk size: %d", len(chunk)))
+        }
+    }
+
+    // case 2: inserting bytes at the head only affects the first chunks
+    modified := append([]byte("some prefix"), data...)
+    modifiedChunks := map[string]bool{}
+    for chunk := range CDCChunks(bytes.NewReader(modified), 1024).FilterOK() {
+        modifiedChunks[string(chunk)] = true
+    }
+    same := 0
+    for _, chunk := range chunks {
+        if modifiedChunks[string(chunk)] {
+            same++
+        }
+    }
+    if same < len(chunks)-2 {
+        t.Fatal(fmt.Sprintf("expect at least %d same chunks, actual: %d", len(chunks)-2, same))
+    }
+
+    // case 3
+    count := Count2(CDCChunks(bytes.NewReader(data), 0))
+    if count != 0 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", 0, count))
+    }
+    count = Count2(CDCChunks(bytes.NewReader(nil), 1024))
+    if count != 0 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", 0, count))
+    }
+
+    for _, _ = range CDCChunks(bytes.NewReader(data), 1024) {
+        break
+    }
+
+    // case 4: a read error is yielded last, and the truncated chunk is not yielded
+    failing := io.MultiReader(bytes.NewReader(data[:10000]), iotest.ErrReader(errors.New("disk failure")))
+    total := 0
+    var lastErr error
+    for chunk, err := range CDCChunks(failing, 1024) {
+        total += len(chunk)
+        lastErr = err
+    }
+    if lastErr == nil || lastErr.Error() != "disk failure" {
+        t.Fatal(fmt.Sprintf("expect disk failure, actual: %v", lastErr))
+    }
+    if total >= 10000 {
+        t.Fatal(fmt.Sprintf("expect the truncated chunk to be discarded, actual: %d bytes yielded", total))
+    }
+}
+
+func TestHashChunks(t *testing.T) {
+    input := [][]byte{[]byte("hello"), []byte("world")}
+    actual := [][]byte{}
+    for chunk, digest := range HashChunks(SliceElems(input), sha256.New) {
+        expect := sha256.Sum256(chunk)
+        if !bytes.Equal(expect[:], digest) {
+            t.Fatal(fmt.Sprintf("expect: %x, actual: %x", expect, digest))
+        }
+        actual = append(actual, chunk)
+    }
+    if len(actual) != 2 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", 2, len(actual)))
+    }
+
+    for _, _ = range HashChunks(SliceElems(input), sha256.New) {
+        break
+    }
+}
+
+func TestMerkleRoot(t *testing.T) {
+    sum := func(data ...[]byte) []byte {
+        h := sha256.New()
+        for _, each := range data {
+            h.Write(each)
+        }
+        return h.Sum(nil)
+    }
+    chunks := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e"), []byte("f")}
+    leaf := func(chunk []byte) []byte {
+        return sum([]byte{0x00}, sum(chunk))
+    }
+    node := func(left, right []byte) []byte {
+        return sum([]byte{0x01}, left, right)
+    }
+    a, b, c, d, e, f := leaf(chunks[0]), leaf(chunks[1]), leaf(chunks[2]), leaf(chunks[3]), leaf(chunks[4]), leaf(chunks[5])
+
+    testCases := []struct {
+        n      int
+        expect []byte
+    }{
+        {0, sum()},
+        {1, a},
+        {2, node(a, b)},
+        {3, node(node(a, b), c)},
+        {5, node(node(node(a, b), node(c, d)), e)},
+        {6, node(node(node(a, b), node(c, d)), node(e, f))},
+    }
+    for _, tc := range testCases {
+        actual := MerkleRoot(HashChunks(SliceElems(chunks[:tc.n]), sha256.New), sha256.New)
+        if !bytes.Equal(tc.expect, actual) {
+            t.Fatal(fmt.Sprintf("n: %d, expect: %x, actual: %x", tc.n, tc.expect, actual))
+        }
+    }
+
+    // an internal node can't be passed off as a leaf
+    forged := Zip(Items([]byte("forged")), Items(node(a, b)))
+    if bytes.Equal(MerkleRoot(forged, sha256.New), node(a, b)) {
+        t.Fatal("expect the internal node not to be accepted as a leaf")
+    }
+}
@@ -0,0 +1,77 @@
+package goiter
+
+// ToMap collects the 2-tuples provided by the input iterator into a map, the first element of each tuple is the key and the second is the value.
+// If a key appears more than once, the later value overwrites the earlier one.
+// For example:
+//
+//  m := goiter.ToMap(goiter.Swap(goiter.Slice([]string{"a", "b"})))  // m will be map[string]int{"a": 0, "b": 1}
+func ToMap[TIter Seq2X[K, V], K comparable, V any](iterator TIter) map[K]V {
+    result := map[K]V{}
+    for k, v := range iterator {
+        result[k] = v
+    }
+    return result
+}
+
+// KeyBy collects the values provided by the input iterator into a map, the key of each value is returned by the keySelector function.
+// If more than one value has the same key, the later value overwrites the earlier one.
+// For example:
+//
+//  m := goiter.KeyBy(goiter.Items("apple", "banana"), func(s string) byte {
+//      return s[0]
+//  })  // m will be map[byte]string{'a': "apple", 'b': "banana"}
+func KeyBy[TIter SeqX[T], T any, K comparable](
+    iterator TIter,
+    keySelector func(T) K,
+) map[K]T {
+    result := map[K]T{}
+    for v := range iterator {
+        result[keySelector(v)] = v
+    }
+    return result
+}
+
+// ToMultiMap is like ToMap, but the values of the same key are appended to a slice instead of overwriting each other.
+// For example:
+//
+//  m := goiter.ToMultiMap(goiter.Transform12(goiter.Items("apple", "banana", "avocado"), func(s string) (byte, string) {
+//      return s[0], s
+//  }))  // m will be map[byte][]string{'a': {"apple", "avocado"}, 'b': {"banana"}}
+func ToMultiMap[TIter Seq2X[K, V], K comparable, V any](iterator TIter) map[K][]V {
+    result := map[K][]V{}
+    for k, v := range iterator {
+        result[k] = append(result[k], v)
+    }
+    return result
+}
+
+// ToSlice collects the values provided by the input iterator into a slice.
+// The optional capHint parameter is used as the initial capacity of the slice, to reduce reallocations when the number of values is known in advance.
+func ToSlice[TIter SeqX[T], T any](iterator TIter, capHint ...int) []T {
+    result := make([]T, 0, sliceCapHint(capHint))
+    for v := range iterator {
+        result = append(result, v)
+    }
+    return result
+}
+
+// ToSlices2 collects the 2-tuples provided by the input iterator into two parallel slices,
+// the first slice holds the first elements and the second slice holds the second elements.
+// The optional capHint parameter is used as the initial capacity of both slices.
+func ToSlices2[TIter Seq2X[T1, T2], T1, T2 any](iterator TIter, capHint ...int) ([]T1, []T2) {
+    c := sliceCapHint(capHint)
+    result1 := make([]T1, 0, c)
+    result2 := make([]T2, 0, c)
+    for v1, v2 := range iterator {
+        result1 = append(result1, v1)
+        result2 = append(result2, v2)
+    }
+    return result1, result2
+}
+
+func sliceCapHint(capHint []int) int {
+    if len(capHint) > 0 && capHint[0] > 0 {
+        return capHint[0]
+    }
+    return 0
+}
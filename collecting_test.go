@@ -0,0 +1,72 @@
+package goiter
+
+import (
+    "fmt"
+    "maps"
+    "slices"
+    "testing"
+)
+
+func TestToMap(t *testing.T) {
+    actual := ToMap(Swap(Slice([]string{"a", "b", "a"})))
+    expect := map[string]int{"a": 2, "b": 1}
+    if !maps.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = ToMap(Empty2[string, int]())
+    expect = map[string]int{}
+    if !maps.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestKeyBy(t *testing.T) {
+    actual := KeyBy(Items("apple", "banana", "avocado"), func(s string) byte {
+        return s[0]
+    })
+    expect := map[byte]string{'a': "avocado", 'b': "banana"}
+    if !maps.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestToMultiMap(t *testing.T) {
+    actual := ToMultiMap(Transform12(Items("apple", "banana", "avocado"), func(s string) (byte, string) {
+        return s[0], s
+    }))
+    expect := map[byte][]string{'a': {"apple", "avocado"}, 'b': {"banana"}}
+    if !maps.EqualFunc(expect, actual, slices.Equal[[]string]) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestToSlice(t *testing.T) {
+    actual := ToSlice(Range(1, 3))
+    expect := []int{1, 2, 3}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = Range(1, 3).ToSlice(10)
+    if !slices.Equal(expect, actual) || cap(actual) != 10 {
+        t.Fatal(fmt.Sprintf("expect: %v with cap 10, actual: %v with cap %d", expect, actual, cap(actual)))
+    }
+
+    actual = ToSlice(Empty[int]())
+    if actual == nil || len(actual) != 0 {
+        t.Fatal(fmt.Sprintf("expect an empty slice, actual: %v", actual))
+    }
+}
+
+func TestToSlices2(t *testing.T) {
+    keys, values := ToSlices2(Slice([]string{"a", "b"}))
+    if !slices.Equal([]int{0, 1}, keys) || !slices.Equal([]string{"a", "b"}, values) {
+        t.Fatal(fmt.Sprintf("unexpected result: %v %v", keys, values))
+    }
+
+    keys, values = Slice([]string{"a", "b"}).ToSlices(4)
+    if !slices.Equal([]int{0, 1}, keys) || !slices.Equal([]string{"a", "b"}, values) || cap(keys) != 4 || cap(values) != 4 {
+        t.Fatal(fmt.Sprintf("unexpected result: %v %v", keys, values))
+    }
+}
@@ -21,6 +21,29 @@ type Zipped[T1, T2 any] struct {
     OK2 bool
 }
 
+// combineSeq is like Combine, but it returns an iter.Seq, so that the iter.Seq2 versions of the buffering operators
+// can reuse the iter.Seq implementations without instantiating Iterator[*Combined[T1, T2]], whose methods would instantiate it recursively.
+func combineSeq[T1, T2 any](iterator iter.Seq2[T1, T2]) iter.Seq[*Combined[T1, T2]] {
+    return func(yield func(*Combined[T1, T2]) bool) {
+        for v1, v2 := range iterator {
+            if !yield(&Combined[T1, T2]{V1: v1, V2: v2}) {
+                return
+            }
+        }
+    }
+}
+
+// uncombineSeq is the reverse of combineSeq.
+func uncombineSeq[T1, T2 any](iterator iter.Seq[*Combined[T1, T2]]) Iterator2[T1, T2] {
+    return func(yield func(T1, T2) bool) {
+        for c := range iterator {
+            if !yield(c.V1, c.V2) {
+                return
+            }
+        }
+    }
+}
+
 // Combine returns an iterator that yields combined values, where each value contains the elements of the 2-Tuple provided by the input iterator.
 func Combine[TIter Seq2X[T1, T2], T1, T2 any](iterator TIter) Iterator[*Combined[T1, T2]] {
     return Transform21(iterator, Combiner[T1, T2])
@@ -58,6 +81,34 @@ func Zip[TIter1 SeqX[T1], TIter2 SeqX[T2], T1, T2 any](
     }
 }
 
+// ZipWith is like Zip, but it combines each pair of elements with the combiner function and yields the results,
+// so no intermediate 2-tuples are created when the next step would transform them anyway.
+// For example:
+//
+//	it1 yields  1   2   3
+//	it2 yields 10  20  30  40
+//	ZipWith(it1, it2, func(a, b int) int { return a + b }) will yield 11 22 33
+func ZipWith[TIter1 SeqX[T1], TIter2 SeqX[T2], TOut, T1, T2 any](
+    iterator1 TIter1,
+    iterator2 TIter2,
+    combiner func(T1, T2) TOut,
+) Iterator[TOut] {
+    return func(yield func(TOut) bool) {
+        p2, stop2 := iter.Pull(iter.Seq[T2](iterator2))
+        defer stop2()
+
+        for v1 := range iterator1 {
+            v2, ok2 := p2()
+            if !ok2 {
+                return
+            }
+            if !yield(combiner(v1, v2)) {
+                return
+            }
+        }
+    }
+}
+
 // ZipAs is a more general version of Zip.
 // if exhaust parameter is true, the resulting iterator will not stop until both input iterators stop, and Zipped.OK1 and Zipped.OK2 will be false when the corresponding iterator stops.
 func ZipAs[TIter1 SeqX[T1], TIter2 SeqX[T2], TOut, T1, T2 any](
@@ -146,3 +197,143 @@ func Concat2[TIter Seq2X[T1, T2], T1 any, T2 any](
         }
     }
 }
+
+// PadEnd returns an iterator that yields the values of the input iterator, followed by as many fill values as needed
+// to make the total number of values at least minLen. If the input iterator yields minLen values or more, nothing is appended.
+// It is useful for generating fixed-width records, or for making two iterators the same length before zipping them.
+// For example:
+//
+//  goiter.PadEnd(goiter.Items(1, 2), 4, 0)           // yields 1 2 0 0
+//  goiter.PadEnd(goiter.Items(1, 2, 3, 4, 5), 4, 0)  // yields 1 2 3 4 5
+func PadEnd[TIter SeqX[T], T any](iterator TIter, minLen int, fill T) Iterator[T] {
+    return func(yield func(T) bool) {
+        count := 0
+        for v := range iterator {
+            if !yield(v) {
+                return
+            }
+            count++
+        }
+        for ; count < minLen; count++ {
+            if !yield(fill) {
+                return
+            }
+        }
+    }
+}
+
+// ProductFunc returns an iterator that yields the dependent cartesian product of the input iterator and the iterators created by makeB.
+// For each value a yielded by the input iterator, makeB(a) is called to create the inner iterator, and (a, b) is yielded for each value b of it.
+// So the inner iterator is regenerated for each outer value, and it can depend on the outer value.
+// For example:
+//
+//  iterator := goiter.ProductFunc(goiter.Range(1, 3), func(a int) goiter.Iterator[int] {
+//      return goiter.Range(a, 3)
+//  })  // iterator will yield (1, 1) (1, 2) (1, 3) (2, 2) (2, 3) (3, 3)
+func ProductFunc[TIterA SeqX[A], TIterB SeqX[B], A, B any](
+    iterator TIterA,
+    makeB func(A) TIterB,
+) Iterator2[A, B] {
+    return func(yield func(A, B) bool) {
+        for a := range iterator {
+            for b := range makeB(a) {
+                if !yield(a, b) {
+                    return
+                }
+            }
+        }
+    }
+}
+
+// Enrich returns an iterator that pairs each value of the input iterator with the reference data found by lookup.
+// Values for which lookup reports false are skipped.
+// For example:
+//
+//  iterator := goiter.Enrich(events, func(e Event) (User, bool) {
+//      return userCache.Get(e.UserID)
+//  })  // iterator yields (event, user) pairs
+func Enrich[TIter SeqX[T], T any, E any](iterator TIter, lookup func(T) (E, bool)) Iterator2[T, E] {
+    return func(yield func(T, E) bool) {
+        for v := range iterator {
+            e, ok := lookup(v)
+            if !ok {
+                continue
+            }
+            if !yield(v, e) {
+                return
+            }
+        }
+    }
+}
+
+// MissingKeyPolicy decides what EnrichFromMap does with the values whose key is not in the map.
+// To stop the iteration with an error on a missing key, use EnrichFromMapE instead.
+type MissingKeyPolicy int
+
+const (
+    // MissingSkip skips the values whose key is missing.
+    MissingSkip MissingKeyPolicy = iota
+    // MissingZero pairs the values whose key is missing with the zero value.
+    MissingZero
+)
+
+// EnrichFromMap is like Enrich function, but the reference data is looked up in m by the key computed by keySelector.
+// The policy decides how the values whose key is not in m are handled, see MissingKeyPolicy.
+// For example:
+//
+//  users := map[int]User{1: {Name: "alice"}}
+//  iterator := goiter.EnrichFromMap(events, users, func(e Event) int {
+//      return e.UserID
+//  }, goiter.MissingZero)
+func EnrichFromMap[TIter SeqX[T], T any, K comparable, E any](
+    iterator TIter,
+    m map[K]E,
+    keySelector func(T) K,
+    policy MissingKeyPolicy,
+) Iterator2[T, E] {
+    return func(yield func(T, E) bool) {
+        for v := range iterator {
+            e, ok := m[keySelector(v)]
+            if !ok && policy == MissingSkip {
+                continue
+            }
+            if !yield(v, e) {
+                return
+            }
+        }
+    }
+}
+
+// EnrichFromMapE is like EnrichFromMap, but a missing key is a failure: each value is yielded paired with its reference data
+// and a nil error, and when a key is not in m, a nil pair and a *MissingKeyError are yielded and the iteration stops.
+// For example:
+//
+//  for pair, err := range goiter.EnrichFromMapE(events, users, func(e Event) int {
+//      return e.UserID
+//  }) {
+//      if err != nil {
+//          return err
+//      }
+//      fmt.Println(pair.V1.Action, pair.V2.Name)
+//  }
+func EnrichFromMapE[TIter SeqX[T], T any, K comparable, E any](
+    iterator TIter,
+    m map[K]E,
+    keySelector func(T) K,
+) IteratorE[*Combined[T, E]] {
+    return func(yield func(*Combined[T, E], error) bool) {
+        idx := -1
+        for v := range iterator {
+            idx++
+            key := keySelector(v)
+            e, ok := m[key]
+            if !ok {
+                yield(nil, &MissingKeyError{Index: idx, Key: key})
+                return
+            }
+            if !yield(&Combined[T, E]{V1: v, V2: e}, nil) {
+                return
+            }
+        }
+    }
+}
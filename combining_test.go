@@ -1,6 +1,7 @@
 package goiter
 
 import (
+    "errors"
     "fmt"
     "maps"
     "slices"
@@ -66,6 +67,52 @@ func TestZip(t *testing.T) {
     }
 }
 
+func TestZipWith(t *testing.T) {
+    add := func(a, b int) int {
+        return a + b
+    }
+    actual := ZipWith(Items(1, 2, 3), Items(10, 20, 30, 40), add).ToSlice()
+    expect := []int{11, 22, 33}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = ZipWith(Items(1, 2, 3, 4), Items(10, 20), add).ToSlice()
+    expect = []int{11, 22}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    labels := ZipWith(Items("a", "b"), Counter(1), func(s string, i int) string {
+        return fmt.Sprintf("%s%d", s, i)
+    }).Take(1).ToSlice()
+    if !slices.Equal([]string{"a1"}, labels) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []string{"a1"}, labels))
+    }
+}
+
+func TestPadEnd(t *testing.T) {
+    actual := PadEnd(Items(1, 2), 4, 0).ToSlice()
+    if !slices.Equal([]int{1, 2, 0, 0}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2, 0, 0}, actual))
+    }
+
+    actual = Items(1, 2, 3, 4, 5).PadEnd(4, 0).ToSlice()
+    if !slices.Equal([]int{1, 2, 3, 4, 5}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2, 3, 4, 5}, actual))
+    }
+
+    actual = PadEnd(Empty[int](), 3, -1).Take(2).ToSlice()
+    if !slices.Equal([]int{-1, -1}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{-1, -1}, actual))
+    }
+
+    actual = PadEnd(Items(1), -1, 0).ToSlice()
+    if !slices.Equal([]int{1}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1}, actual))
+    }
+}
+
 func TestZipAs(t *testing.T) {
     type person struct {
         Name string
@@ -218,3 +265,105 @@ func TestConcat2(t *testing.T) {
         break
     }
 }
+
+func TestProductFunc(t *testing.T) {
+    actual := []string{}
+    iterator := ProductFunc(Range(1, 3), func(a int) Iterator[int] {
+        return Range(a, 3)
+    })
+    for a, b := range iterator {
+        actual = append(actual, fmt.Sprintf("%d-%d", a, b))
+    }
+    expect := []string{"1-1", "1-2", "1-3", "2-2", "2-3", "3-3"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []string{}
+    for a, b := range ProductFunc(Items("x", "y"), func(a string) Iterator[string] { return Empty[string]() }) {
+        actual = append(actual, a+b)
+    }
+    if len(actual) != 0 {
+        t.Fatal(fmt.Sprintf("expect nothing, actual: %v", actual))
+    }
+
+    for _, _ = range iterator {
+        break
+    }
+}
+
+func TestEnrich(t *testing.T) {
+    names := map[int]string{1: "one", 3: "three"}
+    actual := []string{}
+    iterator := Enrich(Range(1, 4), func(v int) (string, bool) {
+        name, ok := names[v]
+        return name, ok
+    })
+    for v, name := range iterator {
+        actual = append(actual, fmt.Sprintf("%d-%s", v, name))
+    }
+    expect := []string{"1-one", "3-three"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _, _ = range iterator {
+        break
+    }
+}
+
+func TestEnrichFromMap(t *testing.T) {
+    names := map[int]string{1: "one", 3: "three"}
+    key := func(v int) int { return v }
+
+    actual := []string{}
+    for v, name := range EnrichFromMap(Range(1, 4), names, key, MissingSkip) {
+        actual = append(actual, fmt.Sprintf("%d-%s", v, name))
+    }
+    expect := []string{"1-one", "3-three"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []string{}
+    for v, name := range EnrichFromMap(Range(1, 4), names, key, MissingZero) {
+        actual = append(actual, fmt.Sprintf("%d-%s", v, name))
+    }
+    expect = []string{"1-one", "2-", "3-three", "4-"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _, _ = range EnrichFromMap(Range(1, 4), names, key, MissingZero) {
+        break
+    }
+}
+
+func TestEnrichFromMapE(t *testing.T) {
+    names := map[int]string{1: "one", 3: "three"}
+    key := func(v int) int { return v }
+
+    actual := []string{}
+    var err error
+    for pair, e := range EnrichFromMapE(Range(1, 4), names, key) {
+        if e != nil {
+            err = e
+            continue
+        }
+        actual = append(actual, fmt.Sprintf("%d-%s", pair.V1, pair.V2))
+    }
+    expect := []string{"1-one"}
+    var missing *MissingKeyError
+    if !slices.Equal(expect, actual) || !errors.As(err, &missing) || missing.Index != 1 || missing.Key != 2 {
+        t.Fatal(fmt.Sprintf("expect %v and MissingKeyError of key 2 at index 1, actual: %v, %v", expect, actual, err))
+    }
+
+    pairs, err := EnrichFromMapE(Range(1, 3), map[int]string{1: "one", 2: "two", 3: "three"}, key).Collect()
+    if len(pairs) != 3 || pairs[2].V2 != "three" || err != nil {
+        t.Fatal(fmt.Sprintf("expect 3 pairs without error, actual: %v, %v", pairs, err))
+    }
+
+    for _, _ = range EnrichFromMapE(Range(1, 4), names, key) {
+        break
+    }
+}
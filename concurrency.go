@@ -0,0 +1,146 @@
+package goiter
+
+import (
+    "context"
+    "runtime/debug"
+    "sync"
+)
+
+// Broadcast consumes the input iterator in a separate goroutine and sends every value to each of the n returned channels,
+// so that several independent consumers can process the same stream.
+// Each channel buffers up to buf values, once the buffer of any channel is full, the broadcasting waits for that consumer,
+// so the memory usage is bounded, and the slowest consumer decides the pace.
+// All channels are closed when the input iterator is exhausted or ctx is cancelled,
+// consumers that stop early should cancel ctx, otherwise the broadcasting blocks forever.
+// If the input iterator panics, the panic is recovered, the channels are closed, and a *PanicError wrapping the panic value is stored into *errp,
+// which can be read by any consumer once its channel is closed, so the consumer can tell a crashed source apart from an exhausted one.
+// If errp is nil, the panic is not recovered.
+// For example:
+//
+//  ctx, cancel := context.WithCancel(context.Background())
+//  defer cancel()
+//  var err error
+//  chs := goiter.Broadcast(ctx, events, 2, 16, &err)
+//  go archive(chs[0])
+//  go index(chs[1])
+//
+// If n is less than or equal to 0, it returns nil and the input iterator is not consumed.
+func Broadcast[TIter SeqX[T], T any](ctx context.Context, iterator TIter, n int, buf int, errp *error) []<-chan T {
+    if n <= 0 {
+        return nil
+    }
+
+    chs := make([]chan T, n)
+    result := make([]<-chan T, n)
+    for i := range chs {
+        chs[i] = make(chan T, max(buf, 0))
+        result[i] = chs[i]
+    }
+
+    go func() {
+        defer func() {
+            for _, ch := range chs {
+                close(ch)
+            }
+        }()
+        if errp != nil {
+            defer func() {
+                if r := recover(); r != nil {
+                    *errp = &PanicError{Value: r, Stack: debug.Stack()}
+                }
+            }()
+        }
+        if ctx.Err() != nil {
+            return
+        }
+        for v := range iterator {
+            for _, ch := range chs {
+                select {
+                case ch <- v:
+                case <-ctx.Done():
+                    return
+                }
+            }
+        }
+    }()
+    return result
+}
+
+// FanIn returns an iterator that consumes all input iterators in parallel, each in its own goroutine,
+// and yields the values as they arrive, so the order of the values is not determined.
+// Unlike Concat, which iterates the input iterators one after another, a slow input iterator doesn't hold up the others.
+// The iteration ends when all input iterators are exhausted or ctx is cancelled.
+// For example:
+//
+//  iterator := goiter.FanIn(ctx, shard1.Records(), shard2.Records(), shard3.Records())
+//
+// If the consumer breaks out of the loop or ctx is cancelled, the goroutines exit the next time their input iterators produce a value,
+// so an input iterator that blocks forever keeps its goroutine alive.
+// If an input iterator panics, the panic is recovered in its goroutine, the other goroutines are stopped,
+// and the consuming goroutine panics with a *PanicError wrapping the panic value, instead of crashing the process.
+func FanIn[TIter SeqX[T], T any](ctx context.Context, iterators ...TIter) Iterator[T] {
+    if len(iterators) == 0 {
+        return Empty[T]()
+    }
+
+    return func(yield func(T) bool) {
+        innerCtx, cancel := context.WithCancel(ctx)
+        defer cancel()
+
+        values := make(chan T)
+        // panics holds the first panic of the input iterators, it is sent before cancel is called and before values is closed
+        panics := make(chan *PanicError, 1)
+        var wg sync.WaitGroup
+        for _, iterator := range iterators {
+            wg.Add(1)
+            go func() {
+                defer wg.Done()
+                defer func() {
+                    if r := recover(); r != nil {
+                        select {
+                        case panics <- &PanicError{Value: r, Stack: debug.Stack()}:
+                        default:
+                        }
+                        cancel()
+                    }
+                }()
+                for v := range iterator {
+                    select {
+                    case values <- v:
+                    case <-innerCtx.Done():
+                        return
+                    }
+                }
+            }()
+        }
+        go func() {
+            wg.Wait()
+            close(values)
+        }()
+
+        for {
+            select {
+            case v, ok := <-values:
+                if !ok {
+                    repanic(panics)
+                    return
+                }
+                if !yield(v) {
+                    return
+                }
+            case <-innerCtx.Done():
+                repanic(panics)
+                return
+            }
+        }
+    }
+}
+
+// repanic panics with the panic recovered from a helper goroutine, if there is one.
+func repanic(panics <-chan *PanicError) {
+    select {
+    case p := <-panics:
+        panic(p)
+    default:
+    }
+}
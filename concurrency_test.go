@@ -0,0 +1,119 @@
+package goiter
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "slices"
+    "sync"
+    "testing"
+)
+
+func TestBroadcast(t *testing.T) {
+    chs := Broadcast(context.Background(), Range(1, 100), 3, 4, nil)
+    if len(chs) != 3 {
+        t.Fatal(fmt.Sprintf("expect 3 channels, actual: %d", len(chs)))
+    }
+    results := make([][]int, 3)
+    var wg sync.WaitGroup
+    for i, ch := range chs {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for v := range ch {
+                results[i] = append(results[i], v)
+            }
+        }()
+    }
+    wg.Wait()
+    expect := Range(1, 100).ToSlice()
+    for i, actual := range results {
+        if !slices.Equal(expect, actual) {
+            t.Fatal(fmt.Sprintf("expect subscriber %d to receive all values, actual: %v", i, actual))
+        }
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    chs = Broadcast(ctx, Counter(0), 2, 0, nil)
+    <-chs[0]
+    cancel()
+    for _, ch := range chs {
+        for range ch {
+        }
+    }
+
+    errBoom := errors.New("boom")
+    panicking := Iterator[int](func(yield func(int) bool) {
+        if yield(1) {
+            panic(errBoom)
+        }
+    })
+    var err error
+    chs = Broadcast(context.Background(), panicking, 2, 1, &err)
+    for _, ch := range chs {
+        actual := []int{}
+        for v := range ch {
+            actual = append(actual, v)
+        }
+        if !slices.Equal([]int{1}, actual) {
+            t.Fatal(fmt.Sprintf("expect: [1], actual: %v", actual))
+        }
+    }
+    var panicErr *PanicError
+    if !errors.As(err, &panicErr) || !errors.Is(err, errBoom) {
+        t.Fatal(fmt.Sprintf("expect a PanicError wrapping errBoom, actual: %v", err))
+    }
+
+    if chs := Broadcast(context.Background(), Range(1, 3), 0, 1, nil); chs != nil {
+        t.Fatal(fmt.Sprintf("expect nil, actual: %v", chs))
+    }
+}
+
+func TestFanIn(t *testing.T) {
+    actual := FanIn(context.Background(), Range(1, 50), Range(51, 100), Empty[int]()).ToSlice()
+    slices.Sort(actual)
+    if !slices.Equal(Range(1, 100).ToSlice(), actual) {
+        t.Fatal(fmt.Sprintf("expect all values, actual: %v", actual))
+    }
+
+    actual = FanIn(context.Background(), Counter(0), Counter(0)).Take(10).ToSlice()
+    if len(actual) != 10 {
+        t.Fatal(fmt.Sprintf("expect 10 values, actual: %v", actual))
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    count := 0
+    for _ = range FanIn(ctx, Counter(0)) {
+        count++
+        if count == 5 {
+            cancel()
+        }
+    }
+    if count < 5 {
+        t.Fatal(fmt.Sprintf("expect at least 5 values, actual: %d", count))
+    }
+
+    errBoom := errors.New("boom")
+    panicking := Iterator[int](func(yield func(int) bool) {
+        if yield(1) {
+            panic(errBoom)
+        }
+    })
+    func() {
+        defer func() {
+            r := recover()
+            if p, ok := r.(*PanicError); !ok || !errors.Is(p, errBoom) {
+                t.Fatal(fmt.Sprintf("expect a PanicError wrapping errBoom, actual: %v", r))
+            }
+        }()
+        for _ = range FanIn(context.Background(), panicking, Counter(0)) {
+        }
+        t.Fatal("expect the panic to reach the consumer")
+    }()
+
+    actual = FanIn[Iterator[int]](context.Background()).ToSlice()
+    if len(actual) != 0 {
+        t.Fatal(fmt.Sprintf("expect empty, actual: %v", actual))
+    }
+}
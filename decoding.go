@@ -0,0 +1,481 @@
+package goiter
+
+import (
+    "bufio"
+    "context"
+    "encoding"
+    "encoding/csv"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "reflect"
+    "strconv"
+    "strings"
+    "unicode"
+)
+
+// DecodeJSONArray returns an iterator that lazily decodes the elements of a JSON array read from r.
+// If the input doesn't start with '[', it is decoded as a stream of concatenated JSON values, such as NDJSON.
+// Each element is yielded with a nil error, if decoding fails, the zero value and the error are yielded and the iteration stops.
+// For example:
+//
+//  r := strings.NewReader(`[{"name": "alice"}, {"name": "bob"}]`)
+//  for user, err := range goiter.DecodeJSONArray[User](r) {
+//      if err != nil {
+//          return err
+//      }
+//      ...
+//  }
+func DecodeJSONArray[T any](r io.Reader) Iterator2[T, error] {
+    return func(yield func(T, error) bool) {
+        var zero T
+        br := bufio.NewReader(r)
+        isArray, err := startsWithArray(br)
+        if err != nil {
+            if err != io.EOF {
+                yield(zero, err)
+            }
+            return
+        }
+
+        dec := json.NewDecoder(br)
+        if isArray {
+            if _, err := dec.Token(); err != nil {
+                yield(zero, err)
+                return
+            }
+        }
+        for {
+            if isArray && !dec.More() {
+                if _, err := dec.Token(); err != nil {
+                    yield(zero, err)
+                }
+                return
+            }
+            var v T
+            if err := dec.Decode(&v); err != nil {
+                if err != io.EOF || isArray {
+                    if err == io.EOF {
+                        err = io.ErrUnexpectedEOF
+                    }
+                    yield(zero, err)
+                }
+                return
+            }
+            if !yield(v, nil) {
+                return
+            }
+        }
+    }
+}
+
+// startsWithArray skips the leading white spaces of br, and reports whether the next byte is '['.
+func startsWithArray(br *bufio.Reader) (bool, error) {
+    for {
+        r, _, err := br.ReadRune()
+        if err != nil {
+            return false, err
+        }
+        if unicode.IsSpace(r) {
+            continue
+        }
+        return r == '[', br.UnreadRune()
+    }
+}
+
+// FromNDJSON returns an iterator that lazily decodes the newline-delimited JSON values read from r, blank lines are ignored.
+// If a line cannot be decoded, the zero value and a *ParseError are yielded, and the iteration continues with the next line.
+// If reading fails, the zero value and the error are yielded and the iteration stops.
+// The iteration also stops after yielding ctx.Err() once ctx is done, however a blocking read cannot be interrupted,
+// so r should be closed when ctx is done, which is what net/http does for the response bodies of the requests with ctx.
+// For example:
+//
+//  resp, _ := http.DefaultClient.Do(req.WithContext(ctx))
+//  defer resp.Body.Close()
+//  for event, err := range goiter.FromNDJSON[Event](ctx, resp.Body) {
+//      ...
+//  }
+func FromNDJSON[T any](ctx context.Context, r io.Reader) Iterator2[T, error] {
+    return func(yield func(T, error) bool) {
+        var zero T
+        br := bufio.NewReader(r)
+        idx := -1
+        for {
+            if err := ctx.Err(); err != nil {
+                yield(zero, err)
+                return
+            }
+            line, err := readLine(br)
+            if err == io.EOF {
+                return
+            }
+            if err != nil {
+                yield(zero, err)
+                return
+            }
+            if strings.TrimSpace(line) == "" {
+                continue
+            }
+            idx++
+            var v T
+            if err := json.Unmarshal([]byte(line), &v); err != nil {
+                if !yield(zero, &ParseError{Index: idx, Input: line, Err: err}) {
+                    return
+                }
+                continue
+            }
+            if !yield(v, nil) {
+                return
+            }
+        }
+    }
+}
+
+// SSEEvent is an event of a server-sent events stream.
+type SSEEvent struct {
+    // ID is the value of the last "id" field.
+    ID string
+    // Event is the value of the "event" field, it is empty for the default "message" type.
+    Event string
+    // Data is the values of the "data" fields of the event, joined by newlines.
+    Data string
+    // Retry is the value of the "retry" field in milliseconds, 0 if it is absent.
+    Retry int
+}
+
+// FromSSE returns an iterator that lazily parses the server-sent events read from r, following the text/event-stream format.
+// Comment lines are ignored, and the events without data are not yielded, as the specification says.
+// The errors and the cancellation by ctx are handled the same as FromNDJSON.
+// For example:
+//
+//  for event, err := range goiter.FromSSE(ctx, resp.Body) {
+//      if err != nil {
+//          return err
+//      }
+//      fmt.Println(event.Event, event.Data)
+//  }
+func FromSSE(ctx context.Context, r io.Reader) Iterator2[SSEEvent, error] {
+    return func(yield func(SSEEvent, error) bool) {
+        br := bufio.NewReader(r)
+        lastID := ""
+        var event SSEEvent
+        var data []string
+        for {
+            if err := ctx.Err(); err != nil {
+                yield(SSEEvent{}, err)
+                return
+            }
+            line, err := readLine(br)
+            if err == io.EOF {
+                return
+            }
+            if err != nil {
+                yield(SSEEvent{}, err)
+                return
+            }
+
+            if line == "" {
+                if len(data) > 0 {
+                    event.ID = lastID
+                    event.Data = strings.Join(data, "\n")
+                    if !yield(event, nil) {
+                        return
+                    }
+                }
+                event = SSEEvent{}
+                data = data[:0]
+                continue
+            }
+            if strings.HasPrefix(line, ":") {
+                continue
+            }
+            field, value, _ := strings.Cut(line, ":")
+            value = strings.TrimPrefix(value, " ")
+            switch field {
+            case "event":
+                event.Event = value
+            case "data":
+                data = append(data, value)
+            case "id":
+                if !strings.Contains(value, "\x00") {
+                    lastID = value
+                }
+            case "retry":
+                if retry, err := strconv.Atoi(value); err == nil {
+                    event.Retry = retry
+                }
+            }
+        }
+    }
+}
+
+// readLine reads a line from br without the trailing "\n" or "\r\n".
+// The last line is returned even if it doesn't end with a newline, io.EOF is returned only if there is nothing left.
+func readLine(br *bufio.Reader) (string, error) {
+    line, err := br.ReadString('\n')
+    if err == io.EOF && line != "" {
+        err = nil
+    }
+    if err != nil {
+        return "", err
+    }
+    line = strings.TrimSuffix(line, "\n")
+    return strings.TrimSuffix(line, "\r"), nil
+}
+
+// CSVOption configures FromCSV and FromCSVAs.
+type CSVOption func(*csvConfig)
+
+type csvConfig struct {
+    comma            rune
+    comment          rune
+    trimLeadingSpace bool
+    skipHeader       bool
+}
+
+// WithCSVComma sets the field delimiter, the default is ','.
+func WithCSVComma(comma rune) CSVOption {
+    return func(c *csvConfig) {
+        c.comma = comma
+    }
+}
+
+// WithCSVComment sets the comment character, lines beginning with it are ignored.
+func WithCSVComment(comment rune) CSVOption {
+    return func(c *csvConfig) {
+        c.comment = comment
+    }
+}
+
+// WithCSVTrimLeadingSpace makes the leading white spaces of the fields ignored.
+func WithCSVTrimLeadingSpace() CSVOption {
+    return func(c *csvConfig) {
+        c.trimLeadingSpace = true
+    }
+}
+
+// WithCSVSkipHeader makes FromCSV skip the first record. FromCSVAs always treats the first record as the header.
+func WithCSVSkipHeader() CSVOption {
+    return func(c *csvConfig) {
+        c.skipHeader = true
+    }
+}
+
+func newCSVReader(r io.Reader, opts []CSVOption) (*csv.Reader, *csvConfig) {
+    config := &csvConfig{comma: ','}
+    for _, opt := range opts {
+        opt(config)
+    }
+    reader := csv.NewReader(r)
+    reader.Comma = config.comma
+    reader.Comment = config.comment
+    reader.TrimLeadingSpace = config.trimLeadingSpace
+    reader.FieldsPerRecord = -1
+    return reader, config
+}
+
+// FromCSV returns an iterator that lazily reads the records of the CSV data from r.
+// Each record is yielded with a nil error, if reading fails, a nil record and the error are yielded and the iteration stops.
+// For example:
+//
+//  for record, err := range goiter.FromCSV(file, goiter.WithCSVSkipHeader()) {
+//      if err != nil {
+//          return err
+//      }
+//      ...
+//  }
+func FromCSV(r io.Reader, opts ...CSVOption) Iterator2[[]string, error] {
+    return func(yield func([]string, error) bool) {
+        reader, config := newCSVReader(r, opts)
+        skip := config.skipHeader
+        for {
+            record, err := reader.Read()
+            if err == io.EOF {
+                return
+            }
+            if err != nil {
+                yield(nil, err)
+                return
+            }
+            if skip {
+                skip = false
+                continue
+            }
+            if !yield(record, nil) {
+                return
+            }
+        }
+    }
+}
+
+// FromCSVAs is like FromCSV, but it maps each record to a struct of type T.
+// The first record is treated as the header, and each column is assigned to the field whose `csv` tag, or name if it has no tag, equals the column name.
+// Fields tagged with `csv:"-"` and columns without a matching field are ignored.
+// The supported field types are strings, booleans, integers, floats and the types implementing encoding.TextUnmarshaler,
+// if a column is mapped to a field of another type, only an error is yielded, before any record is read.
+// If a field cannot be converted, the zero value and a *ParseError are yielded, or an *OverflowError if the number is out of the range of the field,
+// and the iteration continues with the next record.
+// For example:
+//
+//  type User struct {
+//      Name string `csv:"name"`
+//      Age  int    `csv:"age"`
+//  }
+//  for user, err := range goiter.FromCSVAs[User](strings.NewReader("name,age\nalice,20\n")) {
+//      ...
+//  }
+func FromCSVAs[T any](r io.Reader, opts ...CSVOption) Iterator2[T, error] {
+    return func(yield func(T, error) bool) {
+        var zero T
+        typ := reflect.TypeOf(zero)
+        if typ == nil || typ.Kind() != reflect.Struct {
+            yield(zero, fmt.Errorf("goiter: FromCSVAs requires a struct type, got %v", typ))
+            return
+        }
+
+        reader, _ := newCSVReader(r, opts)
+        header, err := reader.Read()
+        if err == io.EOF {
+            return
+        }
+        if err != nil {
+            yield(zero, err)
+            return
+        }
+        fieldIndexes := csvFieldIndexes(typ, header)
+        for _, i := range fieldIndexes {
+            if i >= 0 && !csvFieldSupported(typ.Field(i).Type) {
+                yield(zero, fmt.Errorf("goiter: FromCSVAs field %s: %w %v", typ.Field(i).Name, errUnsupportedCSVField, typ.Field(i).Type))
+                return
+            }
+        }
+
+        idx := -1
+        for {
+            record, err := reader.Read()
+            if err == io.EOF {
+                return
+            }
+            if err != nil {
+                yield(zero, err)
+                return
+            }
+            idx++
+
+            var v T
+            rv := reflect.ValueOf(&v).Elem()
+            var convErr error
+            for col, cell := range record {
+                if col >= len(fieldIndexes) || fieldIndexes[col] < 0 {
+                    continue
+                }
+                if err := setCSVField(rv.Field(fieldIndexes[col]), cell); err != nil {
+                    err = fmt.Errorf("column %q: %w", header[col], err)
+                    if errors.Is(err, strconv.ErrRange) {
+                        convErr = &OverflowError{Index: idx, Input: cell, Err: err}
+                    } else {
+                        convErr = &ParseError{Index: idx, Input: cell, Err: err}
+                    }
+                    break
+                }
+            }
+            if convErr != nil {
+                if !yield(zero, convErr) {
+                    return
+                }
+                continue
+            }
+            if !yield(v, nil) {
+                return
+            }
+        }
+    }
+}
+
+// csvFieldIndexes returns the struct field index for each column of the header, -1 if no field matches the column.
+func csvFieldIndexes(typ reflect.Type, header []string) []int {
+    byName := map[string]int{}
+    for i := 0; i < typ.NumField(); i++ {
+        field := typ.Field(i)
+        if !field.IsExported() {
+            continue
+        }
+        name := field.Name
+        if tag, ok := field.Tag.Lookup("csv"); ok {
+            name, _, _ = strings.Cut(tag, ",")
+            if name == "-" {
+                continue
+            }
+            if name == "" {
+                name = field.Name
+            }
+        }
+        byName[name] = i
+    }
+
+    indexes := make([]int, len(header))
+    for col, name := range header {
+        if i, ok := byName[strings.TrimSpace(name)]; ok {
+            indexes[col] = i
+        } else {
+            indexes[col] = -1
+        }
+    }
+    return indexes
+}
+
+var errUnsupportedCSVField = errors.New("unsupported field type")
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// csvFieldSupported reports whether setCSVField can convert a cell to a field of type typ.
+func csvFieldSupported(typ reflect.Type) bool {
+    if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+        return true
+    }
+    switch typ.Kind() {
+    case reflect.String, reflect.Bool,
+        reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+        reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+        reflect.Float32, reflect.Float64:
+        return true
+    }
+    return false
+}
+
+// setCSVField converts cell to the field, whose type has been checked by csvFieldSupported.
+func setCSVField(field reflect.Value, cell string) error {
+    if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+        return u.UnmarshalText([]byte(cell))
+    }
+    switch field.Kind() {
+    case reflect.String:
+        field.SetString(cell)
+    case reflect.Bool:
+        v, err := strconv.ParseBool(cell)
+        if err != nil {
+            return err
+        }
+        field.SetBool(v)
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        v, err := strconv.ParseInt(cell, 10, field.Type().Bits())
+        if err != nil {
+            return err
+        }
+        field.SetInt(v)
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+        v, err := strconv.ParseUint(cell, 10, field.Type().Bits())
+        if err != nil {
+            return err
+        }
+        field.SetUint(v)
+    case reflect.Float32, reflect.Float64:
+        v, err := strconv.ParseFloat(cell, field.Type().Bits())
+        if err != nil {
+            return err
+        }
+        field.SetFloat(v)
+    }
+    return nil
+}
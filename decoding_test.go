@@ -0,0 +1,267 @@
+package goiter
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "slices"
+    "strings"
+    "testing"
+)
+
+func TestDecodeJSONArray(t *testing.T) {
+    type user struct {
+        Name string `json:"name"`
+    }
+
+    actual := []string{}
+    for u, err := range DecodeJSONArray[user](strings.NewReader(` [{"name": "alice"}, {"name": "bob"}]`)) {
+        if err != nil {
+            t.Fatal(fmt.Sprintf("expect no error, actual: %v", err))
+        }
+        actual = append(actual, u.Name)
+    }
+    expect := []string{"alice", "bob"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []string{}
+    for u, err := range DecodeJSONArray[user](strings.NewReader("{\"name\": \"carol\"}\n{\"name\": \"dave\"}\n")) {
+        if err != nil {
+            t.Fatal(fmt.Sprintf("expect no error, actual: %v", err))
+        }
+        actual = append(actual, u.Name)
+    }
+    expect = []string{"carol", "dave"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []string{}
+    var lastErr error
+    for u, err := range DecodeJSONArray[user](strings.NewReader(`[{"name": "erin"}, {"name": 1}, {"name": "frank"}]`)) {
+        if err != nil {
+            lastErr = err
+            continue
+        }
+        actual = append(actual, u.Name)
+    }
+    expect = []string{"erin"}
+    if !slices.Equal(expect, actual) || lastErr == nil {
+        t.Fatal(fmt.Sprintf("expect: %v and an error, actual: %v, %v", expect, actual, lastErr))
+    }
+
+    lastErr = nil
+    for _, err := range DecodeJSONArray[user](strings.NewReader(`[{"name": "erin"}`)) {
+        lastErr = err
+    }
+    if lastErr == nil {
+        t.Fatal("expect an error for the unterminated array")
+    }
+
+    count := 0
+    for _, _ = range DecodeJSONArray[user](strings.NewReader("  ")) {
+        count++
+    }
+    for _, _ = range DecodeJSONArray[user](strings.NewReader(`[]`)) {
+        count++
+    }
+    if count != 0 {
+        t.Fatal(fmt.Sprintf("expect nothing, actual: %d elements", count))
+    }
+
+    for _, _ = range DecodeJSONArray[user](strings.NewReader(`[{"name": "erin"}, {"name": "frank"}]`)) {
+        break
+    }
+}
+
+func TestFromCSV(t *testing.T) {
+    data := "name;age\n# comment\nalice;20\nbob;3\n"
+    actual := []string{}
+    for record, err := range FromCSV(strings.NewReader(data), WithCSVComma(';'), WithCSVComment('#'), WithCSVSkipHeader()) {
+        if err != nil {
+            t.Fatal(fmt.Sprintf("expect no error, actual: %v", err))
+        }
+        actual = append(actual, strings.Join(record, "="))
+    }
+    expect := []string{"alice=20", "bob=3"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    var lastErr error
+    count := 0
+    for _, err := range FromCSV(strings.NewReader("a,b\n\"c,d\n")) {
+        if err != nil {
+            lastErr = err
+            continue
+        }
+        count++
+    }
+    if count != 1 || lastErr == nil {
+        t.Fatal(fmt.Sprintf("expect 1 record and an error, actual: %d records, %v", count, lastErr))
+    }
+
+    for _, _ = range FromCSV(strings.NewReader(data)) {
+        break
+    }
+}
+
+func TestFromCSVAs(t *testing.T) {
+    type user struct {
+        Name    string  `csv:"name"`
+        Age     int     `csv:"age"`
+        Score   float64 `csv:"score"`
+        Active  bool
+        Ignored string  `csv:"-"`
+    }
+
+    data := "name, age,score,Active,Ignored,extra\nalice,20,1.5,true,x,y\nbob,abc,2,false,x,y\ncarol,30,2.5,false,x,y\n"
+    actual := []string{}
+    var parseErr *ParseError
+    for u, err := range FromCSVAs[user](strings.NewReader(data), WithCSVTrimLeadingSpace()) {
+        if err != nil {
+            if !errors.As(err, &parseErr) {
+                t.Fatal(fmt.Sprintf("expect a ParseError, actual: %v", err))
+            }
+            continue
+        }
+        actual = append(actual, fmt.Sprintf("%s-%d-%v-%v-%s", u.Name, u.Age, u.Score, u.Active, u.Ignored))
+    }
+    expect := []string{"alice-20-1.5-true-", "carol-30-2.5-false-"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+    if parseErr == nil || parseErr.Index != 1 || parseErr.Input != "abc" {
+        t.Fatal(fmt.Sprintf("expect a ParseError of \"abc\" at index 1, actual: %v", parseErr))
+    }
+
+    type small struct {
+        N int8    `csv:"n"`
+        F float32 `csv:"f"`
+    }
+    var errs []error
+    for _, err := range FromCSVAs[small](strings.NewReader("n,f\n1,1\n300,1\n1,1e40\nx,1\n")) {
+        errs = append(errs, err)
+    }
+    var overflowErr *OverflowError
+    if len(errs) != 4 || errs[0] != nil || !errors.As(errs[1], &overflowErr) || overflowErr.Index != 1 || overflowErr.Input != "300" ||
+        !errors.As(errs[2], &overflowErr) || overflowErr.Input != "1e40" || !errors.As(errs[3], &parseErr) || errors.As(errs[3], &overflowErr) {
+        t.Fatal(fmt.Sprintf("expect nil, 2 OverflowErrors and a ParseError, actual: %v", errs))
+    }
+
+    type unsupported struct {
+        Name string   `csv:"name"`
+        Tags []string `csv:"tags"`
+    }
+    errs = nil
+    for _, err := range FromCSVAs[unsupported](strings.NewReader("name,tags\na,x\nb,y\n")) {
+        errs = append(errs, err)
+    }
+    if len(errs) != 1 || !errors.Is(errs[0], errUnsupportedCSVField) {
+        t.Fatal(fmt.Sprintf("expect a single unsupported field error, actual: %v", errs))
+    }
+    // an unsupported field without a matching column is not an error
+    count := 0
+    for _, err := range FromCSVAs[unsupported](strings.NewReader("name\na\nb\n")) {
+        if err != nil {
+            t.Fatal(fmt.Sprintf("expect no error, actual: %v", err))
+        }
+        count++
+    }
+    if count != 2 {
+        t.Fatal(fmt.Sprintf("expect 2 records, actual: %d", count))
+    }
+
+    var lastErr error
+    for _, err := range FromCSVAs[int](strings.NewReader(data)) {
+        lastErr = err
+    }
+    if lastErr == nil {
+        t.Fatal("expect an error for the non-struct type")
+    }
+
+    for _, _ = range FromCSVAs[user](strings.NewReader(data)) {
+        break
+    }
+}
+
+func TestFromNDJSON(t *testing.T) {
+    type point struct {
+        X int `json:"x"`
+    }
+
+    data := "{\"x\": 1}\r\n\n{\"x\": \"bad\"}\n{\"x\": 3}"
+    actual := []int{}
+    var parseErr *ParseError
+    for p, err := range FromNDJSON[point](context.Background(), strings.NewReader(data)) {
+        if err != nil {
+            if !errors.As(err, &parseErr) {
+                t.Fatal(fmt.Sprintf("expect a ParseError, actual: %v", err))
+            }
+            continue
+        }
+        actual = append(actual, p.X)
+    }
+    expect := []int{1, 3}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+    if parseErr == nil || parseErr.Index != 1 {
+        t.Fatal(fmt.Sprintf("expect a ParseError at index 1, actual: %v", parseErr))
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    actual = []int{}
+    var lastErr error
+    for p, err := range FromNDJSON[point](ctx, strings.NewReader(data)) {
+        if err != nil {
+            lastErr = err
+            continue
+        }
+        actual = append(actual, p.X)
+        cancel()
+    }
+    if !slices.Equal([]int{1}, actual) || !errors.Is(lastErr, context.Canceled) {
+        t.Fatal(fmt.Sprintf("expect [1] and context.Canceled, actual: %v, %v", actual, lastErr))
+    }
+
+    for _, _ = range FromNDJSON[point](context.Background(), strings.NewReader(data)) {
+        break
+    }
+}
+
+func TestFromSSE(t *testing.T) {
+    stream := ": keep-alive\n" +
+        "id: 1\n" +
+        "event: greeting\n" +
+        "data: hello\n" +
+        "data: world\n" +
+        "\n" +
+        "retry: 3000\n" +
+        "data:plain\r\n" +
+        "\r\n" +
+        "event: empty\n" +
+        "\n" +
+        "data: incomplete"
+    actual := []SSEEvent{}
+    for event, err := range FromSSE(context.Background(), strings.NewReader(stream)) {
+        if err != nil {
+            t.Fatal(fmt.Sprintf("expect no error, actual: %v", err))
+        }
+        actual = append(actual, event)
+    }
+    expect := []SSEEvent{
+        {ID: "1", Event: "greeting", Data: "hello\nworld"},
+        {ID: "1", Data: "plain", Retry: 3000},
+    }
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _, _ = range FromSSE(context.Background(), strings.NewReader(stream)) {
+        break
+    }
+}
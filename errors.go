@@ -0,0 +1,75 @@
+package goiter
+
+import "fmt"
+
+// ParseError is the error yielded by the parsing operators such as Parse, when an element cannot be parsed.
+// It carries the position and the raw input of the element, so the callers can decide whether to skip the element or abort,
+// and produce precise diagnostics.
+type ParseError struct {
+    // Index is the 0-based position of the element in the input iterator.
+    Index int
+    // Input is the raw input of the element.
+    Input string
+    // Err is the underlying error returned by the parse function.
+    Err error
+}
+
+func (e *ParseError) Error() string {
+    return fmt.Sprintf("goiter: parsing element %d %q: %v", e.Index, e.Input, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+    return e.Err
+}
+
+// OverflowError is like ParseError, but it indicates the element is well-formed, however its value is out of the range of the target type.
+type OverflowError struct {
+    // Index is the 0-based position of the element in the input iterator.
+    Index int
+    // Input is the raw input of the element.
+    Input string
+    // Err is the underlying error returned by the parse function.
+    Err error
+}
+
+func (e *OverflowError) Error() string {
+    return fmt.Sprintf("goiter: element %d %q is out of range: %v", e.Index, e.Input, e.Err)
+}
+
+func (e *OverflowError) Unwrap() error {
+    return e.Err
+}
+
+// MissingKeyError is the error yielded by EnrichFromMapE, when the key of an element is not found in the map.
+type MissingKeyError struct {
+    // Index is the 0-based position of the element in the input iterator.
+    Index int
+    // Key is the key that is not found.
+    Key any
+}
+
+func (e *MissingKeyError) Error() string {
+    return fmt.Sprintf("goiter: key %v of element %d is not found", e.Key, e.Index)
+}
+
+// PanicError is the error yielded by Recover, when the input iterator panics.
+// It is also the panic value of the consuming goroutine, when an input iterator consumed in a helper goroutine panics, such as in FanIn, TimeoutPerElement and DrainOnShutdown,
+// or the error reported by Broadcast when its input iterator panics.
+type PanicError struct {
+    // Value is the value passed to panic.
+    Value any
+    // Stack is the stack trace of the goroutine at the time of the panic.
+    Stack []byte
+}
+
+func (e *PanicError) Error() string {
+    return fmt.Sprintf("goiter: iterator panicked: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+    if err, ok := e.Value.(error); ok {
+        return err
+    }
+    return nil
+}
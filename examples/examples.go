@@ -0,0 +1,148 @@
+// Package examples provides reference pipelines built purely from goiter sources, operators and sinks.
+// They are meant to be read and copied as templates, and they are tested as integration tests of goiter.
+package examples
+
+import (
+    "bufio"
+    "cmp"
+    "io"
+    "strings"
+    "unicode"
+
+    "github.com/hsldymq/goiter"
+)
+
+// Lines returns an iterator that yields the lines read from r, without the line endings.
+// The iteration stops at the end of r, or at the first read error, which is stored into *errp like goiter.OpenLines does,
+// a line longer than bufio.MaxScanTokenSize is reported as bufio.ErrTooLong. *errp is set to nil when the iteration starts,
+// errp can be nil if the read error is not needed.
+func Lines(r io.Reader, errp *error) goiter.Iterator[string] {
+    return func(yield func(string) bool) {
+        if errp != nil {
+            *errp = nil
+        }
+        scanner := bufio.NewScanner(r)
+        for scanner.Scan() {
+            if !yield(scanner.Text()) {
+                return
+            }
+        }
+        if errp != nil {
+            *errp = scanner.Err()
+        }
+    }
+}
+
+// WordCount counts the words read from r, case-insensitively and ignoring the surrounding punctuations.
+// It returns an iterator that yields each word with its count, ordered by the count descending, then by the word.
+// The counts are only complete if no read error is stored into *errp, which is set like Lines does.
+// For example:
+//
+//  var err error
+//  for word, count := range examples.WordCount(strings.NewReader("the cat and the hat"), &err) {
+//      fmt.Println(word, count)
+//  }   // prints: the 2, and 1, cat 1, hat 1
+//  if err != nil {
+//      return err
+//  }
+func WordCount(r io.Reader, errp *error) goiter.Iterator2[string, int] {
+    words := goiter.Filter(
+        goiter.Transform(
+            goiter.PickV2(goiter.ProductFunc(Lines(r, errp), goiter.Words)),
+            normalizeWord,
+        ),
+        func(word string) bool {
+            return word != ""
+        },
+    )
+    counts := goiter.GroupByReduce(words, func(word string) string {
+        return word
+    }, 0, func(acc int, _ string) int {
+        return acc + 1
+    })
+    return goiter.Order2By(counts, func(a, b *goiter.Combined[string, int]) int {
+        if c := cmp.Compare(b.V2, a.V2); c != 0 {
+            return c
+        }
+        return cmp.Compare(a.V1, b.V1)
+    })
+}
+
+func normalizeWord(word string) string {
+    return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+        return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+    }))
+}
+
+// LogSummary is the result of LogStats.
+type LogSummary struct {
+    // Total is the number of well-formed log lines.
+    Total int
+    // Malformed is the number of lines that are not in the "<timestamp> <LEVEL> <message>" form.
+    Malformed int
+    // ByLevel is the number of lines of each level.
+    ByLevel map[string]int
+    // TopErrors is the most frequent messages of the ERROR level lines, at most 3 of them, the most frequent first.
+    TopErrors []string
+}
+
+type logEntry struct {
+    level   string
+    message string
+}
+
+// parseLogLine parses a line in the "<timestamp> <LEVEL> <message>" form, the level of the returned entry is empty if the line is malformed.
+func parseLogLine(line string) logEntry {
+    fields := strings.SplitN(strings.TrimSpace(line), " ", 3)
+    if len(fields) < 3 {
+        return logEntry{}
+    }
+    return logEntry{level: strings.ToUpper(fields[1]), message: strings.TrimSpace(fields[2])}
+}
+
+type logStatsAcc struct {
+    summary     LogSummary
+    errorCounts map[string]int
+}
+
+// LogStats summarizes the log lines in the "<timestamp> <LEVEL> <message>" form, such as "2024-01-02T15:04:05Z ERROR disk full".
+// Blank lines are ignored. If the lines are read by Lines, check its error before using the summary, for example:
+//
+//  var err error
+//  summary := examples.LogStats(examples.Lines(file, &err))
+//  if err != nil {
+//      return err
+//  }
+func LogStats[TIter goiter.SeqX[string]](lines TIter) LogSummary {
+    nonBlank := goiter.Filter(lines, func(line string) bool {
+        return strings.TrimSpace(line) != ""
+    })
+    init := logStatsAcc{
+        summary:     LogSummary{ByLevel: map[string]int{}},
+        errorCounts: map[string]int{},
+    }
+    acc := goiter.Reduce(goiter.Transform(nonBlank, parseLogLine), init, func(acc logStatsAcc, e logEntry) logStatsAcc {
+        if e.level == "" {
+            acc.summary.Malformed++
+            return acc
+        }
+        acc.summary.Total++
+        acc.summary.ByLevel[e.level]++
+        if e.level == "ERROR" {
+            acc.errorCounts[e.message]++
+        }
+        return acc
+    })
+
+    top := goiter.TopKBy(goiter.Combine(goiter.Map(acc.errorCounts)), 3, func(a, b *goiter.Combined[string, int]) int {
+        if c := cmp.Compare(a.V2, b.V2); c != 0 {
+            return c
+        }
+        return cmp.Compare(b.V1, a.V1)
+    })
+    summary := acc.summary
+    summary.TopErrors = goiter.Transform(top, func(c *goiter.Combined[string, int]) string {
+        return c.V1
+    }).ToSlice()
+    return summary
+}
@@ -0,0 +1,105 @@
+package examples
+
+import (
+    "bufio"
+    "errors"
+    "fmt"
+    "maps"
+    "slices"
+    "strings"
+    "testing"
+)
+
+var errRead = errors.New("read failed")
+
+// failingReader yields its content, then fails with errRead.
+type failingReader struct {
+    content string
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+    if r.content == "" {
+        return 0, errRead
+    }
+    n := copy(p, r.content)
+    r.content = r.content[n:]
+    return n, nil
+}
+
+func TestLines(t *testing.T) {
+    err := errRead
+    actual := Lines(strings.NewReader("a\r\nb\n\nc"), &err).ToSlice()
+    expect := []string{"a", "b", "", "c"}
+    if !slices.Equal(expect, actual) || err != nil {
+        t.Fatal(fmt.Sprintf("expect: %q without error, actual: %q, %v", expect, actual, err))
+    }
+
+    actual = Lines(&failingReader{content: "a\nb\n"}, &err).ToSlice()
+    if !slices.Equal([]string{"a", "b"}, actual) || !errors.Is(err, errRead) {
+        t.Fatal(fmt.Sprintf("expect [a b] with errRead, actual: %q, %v", actual, err))
+    }
+
+    actual = Lines(strings.NewReader("a\n"+strings.Repeat("x", bufio.MaxScanTokenSize)+"\nb"), &err).ToSlice()
+    if !slices.Equal([]string{"a"}, actual) || !errors.Is(err, bufio.ErrTooLong) {
+        t.Fatal(fmt.Sprintf("expect [a] with bufio.ErrTooLong, actual: %q, %v", actual, err))
+    }
+
+    actual = Lines(&failingReader{content: "a"}, nil).ToSlice()
+    if !slices.Equal([]string{"a"}, actual) {
+        t.Fatal(fmt.Sprintf("expect: [a], actual: %q", actual))
+    }
+}
+
+func TestWordCount(t *testing.T) {
+    text := "The cat and the hat.\nThe HAT, the cat!\n-- \n"
+    var err error
+    actual := []string{}
+    for word, count := range WordCount(strings.NewReader(text), &err) {
+        actual = append(actual, fmt.Sprintf("%s:%d", word, count))
+    }
+    expect := []string{"the:4", "cat:2", "hat:2", "and:1"}
+    if !slices.Equal(expect, actual) || err != nil {
+        t.Fatal(fmt.Sprintf("expect: %v without error, actual: %v, %v", expect, actual, err))
+    }
+
+    for _, _ = range WordCount(strings.NewReader(text), nil) {
+        break
+    }
+
+    for _, _ = range WordCount(&failingReader{content: text}, &err) {
+    }
+    if !errors.Is(err, errRead) {
+        t.Fatal(fmt.Sprintf("expect errRead, actual: %v", err))
+    }
+}
+
+func TestLogStats(t *testing.T) {
+    logs := `2024-01-02T15:04:05Z INFO server started
+2024-01-02T15:04:06Z error disk full
+2024-01-02T15:04:07Z ERROR timeout
+garbage
+
+2024-01-02T15:04:08Z WARN slow request
+2024-01-02T15:04:09Z ERROR disk full
+2024-01-02T15:04:10Z ERROR connection reset
+2024-01-02T15:04:11Z ERROR bad request
+2024-01-02T15:04:12Z ERROR timeout
+2024-01-02T15:04:13Z ERROR disk full
+`
+    var err error
+    summary := LogStats(Lines(strings.NewReader(logs), &err))
+    if err != nil {
+        t.Fatal(fmt.Sprintf("expect no error, actual: %v", err))
+    }
+    if summary.Total != 9 || summary.Malformed != 1 {
+        t.Fatal(fmt.Sprintf("expect 9 lines and 1 malformed, actual: %d, %d", summary.Total, summary.Malformed))
+    }
+    expectLevels := map[string]int{"INFO": 1, "WARN": 1, "ERROR": 7}
+    if !maps.Equal(expectLevels, summary.ByLevel) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expectLevels, summary.ByLevel))
+    }
+    expectErrors := []string{"disk full", "timeout", "bad request"}
+    if !slices.Equal(expectErrors, summary.TopErrors) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expectErrors, summary.TopErrors))
+    }
+}
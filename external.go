@@ -0,0 +1,532 @@
+package goiter
+
+import (
+    "bufio"
+    "encoding/gob"
+    "encoding/json"
+    "io"
+    "iter"
+    "os"
+    "runtime"
+    "slices"
+    "sync"
+)
+
+// Codec encodes values of type T into a byte stream and decodes them back.
+// It is used by the functions that need to store values outside the memory, such as OrderExternal.
+type Codec[T any] interface {
+    // Encoder returns a function that writes values to w one after another.
+    Encoder(w io.Writer) func(T) error
+    // Decoder returns a function that reads the values written by the encoder from r one after another,
+    // it returns io.EOF when there are no more values.
+    Decoder(r io.Reader) func() (T, error)
+}
+
+// GobCodec returns a Codec that uses encoding/gob, so T must be encodable by gob, for example, struct fields must be exported.
+func GobCodec[T any]() Codec[T] {
+    return gobCodec[T]{}
+}
+
+type gobCodec[T any] struct{}
+
+func (gobCodec[T]) Encoder(w io.Writer) func(T) error {
+    enc := gob.NewEncoder(w)
+    return func(v T) error {
+        return enc.Encode(v)
+    }
+}
+
+func (gobCodec[T]) Decoder(r io.Reader) func() (T, error) {
+    dec := gob.NewDecoder(r)
+    return func() (T, error) {
+        var v T
+        err := dec.Decode(&v)
+        return v, err
+    }
+}
+
+// JSONCodec returns a Codec that uses encoding/json, each value is written as a single line of JSON, so the output is NDJSON.
+func JSONCodec[T any]() Codec[T] {
+    return jsonCodec[T]{}
+}
+
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) Encoder(w io.Writer) func(T) error {
+    enc := json.NewEncoder(w)
+    return func(v T) error {
+        return enc.Encode(v)
+    }
+}
+
+func (jsonCodec[T]) Decoder(r io.Reader) func() (T, error) {
+    dec := json.NewDecoder(r)
+    return func() (T, error) {
+        var v T
+        err := dec.Decode(&v)
+        return v, err
+    }
+}
+
+// ExternalSortOption configures OrderExternal and OrderExternalE.
+type ExternalSortOption func(*externalSortConfig)
+
+type externalSortConfig struct {
+    runSize      int
+    tempDir      string
+    errorHandler func(error)
+}
+
+// WithSortRunSize sets the maximum number of elements that are sorted in memory and spilled to one temporary file, the default is 65536.
+func WithSortRunSize(n int) ExternalSortOption {
+    return func(c *externalSortConfig) {
+        if n > 0 {
+            c.runSize = n
+        }
+    }
+}
+
+// WithSortTempDir sets the directory in which the temporary files are created, the default is os.TempDir().
+func WithSortTempDir(dir string) ExternalSortOption {
+    return func(c *externalSortConfig) {
+        c.tempDir = dir
+    }
+}
+
+// WithSortErrorHandler sets the function that is called when OrderExternal fails to write or read the temporary files,
+// the iteration stops after the handler is called. By default, OrderExternal panics with the error.
+// OrderExternalE ignores it, since it yields the error instead.
+func WithSortErrorHandler(handler func(error)) ExternalSortOption {
+    return func(c *externalSortConfig) {
+        c.errorHandler = handler
+    }
+}
+
+// OrderExternal is like StableOrderBy, but it is designed for the iterators that yield more data than the memory can hold.
+// It sorts the elements in runs of limited size, spills each sorted run to a temporary file, and then merges the runs lazily.
+// At most 64 runs are merged at once, more runs are merged into intermediate runs first, so the number of open files is bounded.
+// If all elements fit in one run, no temporary file is created. The temporary files are removed when the iteration ends.
+// The elements are written to and read from the temporary files with codec, if codec is nil, GobCodec is used.
+// The errors of the temporary files are passed to the handler set by WithSortErrorHandler, use OrderExternalE to receive them as values.
+// For example:
+//
+//  iterator := goiter.OrderExternal(hugeIterator, cmp.Compare[int], nil, goiter.WithSortRunSize(1_000_000), goiter.WithSortTempDir("/data/tmp"))
+func OrderExternal[TIter SeqX[T], T any](
+    iterator TIter,
+    cmp func(T, T) int,
+    codec Codec[T],
+    opts ...ExternalSortOption,
+) Iterator[T] {
+    config := newExternalSortConfig(opts)
+    if codec == nil {
+        codec = GobCodec[T]()
+    }
+    return func(yield func(T) bool) {
+        if err := orderExternal(iter.Seq[T](iterator), cmp, codec, config, yield); err != nil {
+            config.errorHandler(err)
+        }
+    }
+}
+
+// OrderExternalE is like OrderExternal, but each element is yielded with a nil error, and if the temporary files cannot be written or read,
+// the zero value and the error are yielded and the iteration stops. WithSortErrorHandler has no effect on it.
+// For example:
+//
+//  for v, err := range goiter.OrderExternalE(hugeIterator, cmp.Compare[int], nil, goiter.WithSortTempDir("/data/tmp")) {
+//      if err != nil {
+//          return err
+//      }
+//      ...
+//  }
+func OrderExternalE[TIter SeqX[T], T any](
+    iterator TIter,
+    cmp func(T, T) int,
+    codec Codec[T],
+    opts ...ExternalSortOption,
+) IteratorE[T] {
+    config := newExternalSortConfig(opts)
+    if codec == nil {
+        codec = GobCodec[T]()
+    }
+    return func(yield func(T, error) bool) {
+        stopped := false
+        err := orderExternal(iter.Seq[T](iterator), cmp, codec, config, func(v T) bool {
+            stopped = !yield(v, nil)
+            return !stopped
+        })
+        if err != nil && !stopped {
+            var zero T
+            yield(zero, err)
+        }
+    }
+}
+
+func newExternalSortConfig(opts []ExternalSortOption) *externalSortConfig {
+    config := &externalSortConfig{
+        runSize: 65536,
+        errorHandler: func(err error) {
+            panic(err)
+        },
+    }
+    for _, opt := range opts {
+        opt(config)
+    }
+    return config
+}
+
+func orderExternal[T any](iterator iter.Seq[T], cmp func(T, T) int, codec Codec[T], config *externalSortConfig, yield func(T) bool) error {
+    var runFiles []string
+    defer func() {
+        removeFiles(runFiles)
+    }()
+
+    buffer := make([]T, 0, min(config.runSize, 1024))
+    for v := range iterator {
+        buffer = append(buffer, v)
+        if len(buffer) < config.runSize {
+            continue
+        }
+        name, err := spillRun(buffer, cmp, codec, config.tempDir)
+        if name != "" {
+            runFiles = append(runFiles, name)
+        }
+        if err != nil {
+            return err
+        }
+        buffer = buffer[:0]
+    }
+
+    if len(runFiles) == 0 {
+        slices.SortStableFunc(buffer, cmp)
+        for _, each := range buffer {
+            if !yield(each) {
+                return nil
+            }
+        }
+        return nil
+    }
+    if len(buffer) > 0 {
+        name, err := spillRun(buffer, cmp, codec, config.tempDir)
+        if name != "" {
+            runFiles = append(runFiles, name)
+        }
+        if err != nil {
+            return err
+        }
+    }
+    buffer = nil
+
+    var err error
+    if runFiles, err = reduceRuns(runFiles, cmp, codec, config.tempDir); err != nil {
+        return err
+    }
+    return mergeRuns(runFiles, cmp, codec, yield)
+}
+
+// spillRun writes buffer to a temporary file in dir, sorted by cmp unless cmp is nil, and returns the name of the file.
+func spillRun[T any](buffer []T, cmp func(T, T) int, codec Codec[T], dir string) (string, error) {
+    if cmp != nil {
+        slices.SortStableFunc(buffer, cmp)
+    }
+
+    f, err := os.CreateTemp(dir, "goiter-sort-*")
+    if err != nil {
+        return "", err
+    }
+    defer f.Close()
+
+    w := bufio.NewWriter(f)
+    encode := codec.Encoder(w)
+    for _, each := range buffer {
+        if err := encode(each); err != nil {
+            return f.Name(), err
+        }
+    }
+    if err := w.Flush(); err != nil {
+        return f.Name(), err
+    }
+    return f.Name(), f.Close()
+}
+
+type mergeEntry[T any] struct {
+    v   T
+    run int
+}
+
+// maxMergeFanIn is the maximum number of runs merged at once, it bounds the number of files that are open at the same time.
+const maxMergeFanIn = 64
+
+// reduceRuns merges every maxMergeFanIn consecutive runs into an intermediate run, pass after pass, until at most maxMergeFanIn runs are left.
+// Only consecutive runs are merged together, so the final merge is still stable. The merged runs are removed as soon as they are merged.
+// It returns the runs that are left, which should be removed by the caller even if an error is returned.
+func reduceRuns[T any](runFiles []string, cmp func(T, T) int, codec Codec[T], dir string) ([]string, error) {
+    for len(runFiles) > maxMergeFanIn {
+        next := make([]string, 0, (len(runFiles)+maxMergeFanIn-1)/maxMergeFanIn)
+        for i := 0; i < len(runFiles); i += maxMergeFanIn {
+            group := runFiles[i:min(i+maxMergeFanIn, len(runFiles))]
+            if len(group) == 1 {
+                next = append(next, group[0])
+                continue
+            }
+            spill, err := newSpillFile(codec, dir)
+            if err != nil {
+                return append(next, runFiles[i:]...), err
+            }
+            var writeErr error
+            err = mergeRuns(group, cmp, codec, func(v T) bool {
+                writeErr = spill.write(v)
+                return writeErr == nil
+            })
+            if err == nil {
+                err = writeErr
+            }
+            if err == nil {
+                err = spill.close()
+            }
+            if err != nil {
+                spill.discard()
+                return append(next, runFiles[i:]...), err
+            }
+            removeFiles(group)
+            next = append(next, spill.name)
+        }
+        runFiles = next
+    }
+    return runFiles, nil
+}
+
+// mergeRuns merges the sorted runs with a k-way merge, ties are broken by the run index to keep the ordering stable.
+func mergeRuns[T any](runFiles []string, cmp func(T, T) int, codec Codec[T], yield func(T) bool) error {
+    decoders := make([]func() (T, error), len(runFiles))
+    for i, name := range runFiles {
+        f, err := os.Open(name)
+        if err != nil {
+            return err
+        }
+        defer f.Close()
+        decoders[i] = codec.Decoder(bufio.NewReader(f))
+    }
+
+    entryCmp := func(a, b mergeEntry[T]) int {
+        if c := cmp(a.v, b.v); c != 0 {
+            return c
+        }
+        return a.run - b.run
+    }
+    h := make([]mergeEntry[T], 0, len(decoders))
+    for i, decode := range decoders {
+        v, err := decode()
+        if err == io.EOF {
+            continue
+        } else if err != nil {
+            return err
+        }
+        h = append(h, mergeEntry[T]{v: v, run: i})
+    }
+    for i := len(h)/2 - 1; i >= 0; i-- {
+        siftDown(h, i, entryCmp)
+    }
+
+    for len(h) > 0 {
+        top := h[0]
+        if !yield(top.v) {
+            return nil
+        }
+        v, err := decoders[top.run]()
+        if err == io.EOF {
+            last := len(h) - 1
+            h[0] = h[last]
+            h = h[:last]
+        } else if err != nil {
+            return err
+        } else {
+            h[0] = mergeEntry[T]{v: v, run: top.run}
+        }
+        siftDown(h, 0, entryCmp)
+    }
+    return nil
+}
+
+// CacheToDisk is like Cache, but the values are stored in a temporary file in dir instead of the memory,
+// so it is suitable for the iterators that yield more data than the memory can hold.
+// The first complete iteration yields the values of the input iterator and writes them to the file with the codec,
+// the subsequent iterations replay the values from the file. If the first iteration is stopped early, or the file cannot be written,
+// the input iterator is iterated again next time, just like Cache. So like Cache, the input iterator must yield the same values
+// every time it is iterated until the cache is complete, an iterator that can only be iterated once, such as one reading from an io.Reader,
+// has to be iterated to the end the first time.
+// If the file cannot be read when replaying, the iteration stops and the error is stored into *errp, the input iterator is not iterated again.
+// *errp is set to nil when each iteration starts, errp can be nil if the read error is not needed.
+// The temporary file is removed when the returned iterator is garbage collected.
+// For example:
+//
+//  var readErr error
+//  iterator, err := goiter.CacheToDisk(hugeIterator, goiter.GobCodec[Result](), os.TempDir(), &readErr)
+//  if err != nil {
+//      return err
+//  }
+//  for v := range iterator {
+//      ...
+//  }
+//  if readErr != nil {
+//      return readErr
+//  }
+//
+// It returns an error if the temporary file cannot be created.
+func CacheToDisk[TIter SeqX[T], T any](it TIter, codec Codec[T], dir string, errp *error) (Iterator[T], error) {
+    f, err := os.CreateTemp(dir, "goiter-cache-*")
+    if err != nil {
+        return nil, err
+    }
+    name := f.Name()
+    if err := f.Close(); err != nil {
+        _ = os.Remove(name)
+        return nil, err
+    }
+
+    c := &diskCache[T]{name: name, codec: codec}
+    runtime.SetFinalizer(c, func(c *diskCache[T]) {
+        _ = os.Remove(c.name)
+    })
+    return func(yield func(T) bool) {
+        if errp != nil {
+            *errp = nil
+        }
+        if err := c.iterate(iter.Seq[T](it), yield); err != nil && errp != nil {
+            *errp = err
+        }
+    }, nil
+}
+
+type diskCache[T any] struct {
+    mu      sync.Mutex
+    name    string
+    codec   Codec[T]
+    cached  bool
+    writing bool
+}
+
+// iterate returns the error of reading the file when replaying, the errors of writing it only leave the cache incomplete.
+func (c *diskCache[T]) iterate(it iter.Seq[T], yield func(T) bool) error {
+    c.mu.Lock()
+    if c.cached {
+        c.mu.Unlock()
+        return replayFile(c.name, c.codec, yield)
+    }
+    if c.writing {
+        // another iteration is writing the file, so this one just goes through the input iterator.
+        c.mu.Unlock()
+        for v := range it {
+            if !yield(v) {
+                return nil
+            }
+        }
+        return nil
+    }
+    c.writing = true
+    c.mu.Unlock()
+
+    completed := false
+    defer func() {
+        c.mu.Lock()
+        c.writing = false
+        c.cached = completed
+        c.mu.Unlock()
+    }()
+
+    w, writeErr := os.Create(c.name)
+    if writeErr == nil {
+        defer w.Close()
+        bw := bufio.NewWriter(w)
+        encode := c.codec.Encoder(bw)
+        for v := range it {
+            if writeErr == nil {
+                writeErr = encode(v)
+            }
+            if !yield(v) {
+                return nil
+            }
+        }
+        if writeErr == nil {
+            writeErr = bw.Flush()
+        }
+        if writeErr == nil {
+            writeErr = w.Close()
+        }
+        completed = writeErr == nil
+        return nil
+    }
+
+    for v := range it {
+        if !yield(v) {
+            return nil
+        }
+    }
+    return nil
+}
+
+func replayFile[T any](name string, codec Codec[T], yield func(T) bool) error {
+    f, err := os.Open(name)
+    if err != nil {
+        return err
+    }
+    defer f.Close()
+
+    decode := codec.Decoder(bufio.NewReader(f))
+    for {
+        v, err := decode()
+        if err == io.EOF {
+            return nil
+        } else if err != nil {
+            return err
+        }
+        if !yield(v) {
+            return nil
+        }
+    }
+}
+
+// readRun reads all the values written by spillRun back into memory.
+func readRun[T any](name string, codec Codec[T]) ([]T, error) {
+    var values []T
+    err := replayFile(name, codec, func(v T) bool {
+        values = append(values, v)
+        return true
+    })
+    return values, err
+}
+
+// spillFile is a temporary file that values are appended to one by one with a codec.
+type spillFile[T any] struct {
+    name   string
+    f      *os.File
+    w      *bufio.Writer
+    encode func(T) error
+}
+
+func newSpillFile[T any](codec Codec[T], dir string) (*spillFile[T], error) {
+    f, err := os.CreateTemp(dir, "goiter-spill-*")
+    if err != nil {
+        return nil, err
+    }
+    w := bufio.NewWriter(f)
+    return &spillFile[T]{name: f.Name(), f: f, w: w, encode: codec.Encoder(w)}, nil
+}
+
+func (s *spillFile[T]) write(v T) error {
+    return s.encode(v)
+}
+
+// close flushes and closes the file, which is kept for reading.
+func (s *spillFile[T]) close() error {
+    if err := s.w.Flush(); err != nil {
+        _ = s.f.Close()
+        return err
+    }
+    return s.f.Close()
+}
+
+// discard closes and removes the file.
+func (s *spillFile[T]) discard() {
+    _ = s.f.Close()
+    _ = os.Remove(s.name)
+}
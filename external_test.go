@@ -0,0 +1,221 @@
+package goiter
+
+import (
+    "bytes"
+    "cmp"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "slices"
+    "runtime"
+    "testing"
+    "time"
+)
+
+func TestOrderExternal(t *testing.T) {
+    dir := t.TempDir()
+    input := make([]int, 0, 1000)
+    for i := range 1000 {
+        input = append(input, (i*7919)%1000)
+    }
+
+    // case 1: spilled to multiple runs
+    actual := make([]int, 0, len(input))
+    for v := range OrderExternal(SliceElems(input), cmp.Compare[int], nil, WithSortRunSize(64), WithSortTempDir(dir)) {
+        actual = append(actual, v)
+    }
+    expect := slices.Sorted(SliceElems(input).Seq())
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+    if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+        t.Fatal(fmt.Sprintf("expect temporary files to be removed, actual: %d files", len(entries)))
+    }
+
+    // case 2: fits in memory
+    actual = make([]int, 0, 3)
+    for v := range OrderExternal(Items(3, 1, 2), cmp.Compare[int], nil, WithSortTempDir(dir)) {
+        actual = append(actual, v)
+    }
+    expect = []int{1, 2, 3}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    // case 3: stable and breaking out early removes the temporary files
+    type pair struct {
+        Key int
+        Seq int
+    }
+    pairs := make([]pair, 0, 300)
+    for i := range 300 {
+        pairs = append(pairs, pair{Key: i % 3, Seq: i})
+    }
+    cmpPair := func(a, b pair) int { return cmp.Compare(a.Key, b.Key) }
+    actualPairs := make([]pair, 0, len(pairs))
+    for v := range OrderExternal(SliceElems(pairs), cmpPair, GobCodec[pair](), WithSortRunSize(7), WithSortTempDir(dir)) {
+        actualPairs = append(actualPairs, v)
+    }
+    expectPairs := slices.Clone(pairs)
+    slices.SortStableFunc(expectPairs, cmpPair)
+    if !slices.Equal(expectPairs, actualPairs) {
+        t.Fatal("expect stable ordering")
+    }
+    for _ = range OrderExternal(SliceElems(pairs), cmpPair, nil, WithSortRunSize(7), WithSortTempDir(dir)) {
+        break
+    }
+    if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+        t.Fatal(fmt.Sprintf("expect temporary files to be removed, actual: %d files", len(entries)))
+    }
+
+    // case 4: errors are reported to the handler
+    var reported error
+    handler := func(err error) { reported = err }
+    count := OrderExternal(SliceElems(input), cmp.Compare[int], failingCodec{}, WithSortRunSize(64), WithSortErrorHandler(handler)).Count()
+    if count != 0 || !errors.Is(reported, errFailingCodec) {
+        t.Fatal(fmt.Sprintf("expect error to be reported, actual: %v", reported))
+    }
+
+    // case 5: more runs than the fan-in are merged in multiple passes, still stable
+    actualPairs = OrderExternal(SliceElems(pairs), cmpPair, nil, WithSortRunSize(2), WithSortTempDir(dir)).ToSlice()
+    if !slices.Equal(expectPairs, actualPairs) {
+        t.Fatal("expect stable ordering")
+    }
+    if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+        t.Fatal(fmt.Sprintf("expect temporary files to be removed, actual: %d files", len(entries)))
+    }
+}
+
+func TestOrderExternalE(t *testing.T) {
+    dir := t.TempDir()
+    actual, err := OrderExternalE(Range(100, 1), cmp.Compare[int], nil, WithSortRunSize(1), WithSortTempDir(dir)).Collect()
+    if !slices.Equal(Range(1, 100).ToSlice(), actual) || err != nil {
+        t.Fatal(fmt.Sprintf("expect 1 to 100 without error, actual: %v with error %v", actual, err))
+    }
+
+    count := 0
+    var lastErr error
+    for _, err := range OrderExternalE(Range(1, 100), cmp.Compare[int], failingCodec{}, WithSortRunSize(64)) {
+        count++
+        lastErr = err
+    }
+    if count != 1 || !errors.Is(lastErr, errFailingCodec) {
+        t.Fatal(fmt.Sprintf("expect only the error to be yielded, actual: %d elements with error %v", count, lastErr))
+    }
+
+    for _, _ = range OrderExternalE(Range(100, 1), cmp.Compare[int], nil, WithSortRunSize(1), WithSortTempDir(dir)) {
+        break
+    }
+    if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+        t.Fatal(fmt.Sprintf("expect temporary files to be removed, actual: %d files", len(entries)))
+    }
+}
+
+var errFailingCodec = errors.New("failing codec")
+
+type failingCodec struct{}
+
+func (failingCodec) Encoder(w io.Writer) func(int) error {
+    return func(int) error { return errFailingCodec }
+}
+
+func (failingCodec) Decoder(r io.Reader) func() (int, error) {
+    return func() (int, error) { return 0, errFailingCodec }
+}
+
+func TestCacheToDisk(t *testing.T) {
+    dir := t.TempDir()
+    runs := 0
+    source := func(yield func(int) bool) {
+        runs++
+        for i := 1; i <= 5; i++ {
+            if !yield(i) {
+                return
+            }
+        }
+    }
+
+    var readErr error
+    it, err := CacheToDisk(source, GobCodec[int](), dir, &readErr)
+    if err != nil {
+        t.Fatal(fmt.Sprintf("expect no error, actual: %v", err))
+    }
+
+    // breaking early does not complete the cache
+    actual := it.Take(2).ToSlice()
+    if !slices.Equal([]int{1, 2}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2}, actual))
+    }
+
+    for i := 0; i < 3; i++ {
+        actual = it.ToSlice()
+        if !slices.Equal([]int{1, 2, 3, 4, 5}, actual) {
+            t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2, 3, 4, 5}, actual))
+        }
+    }
+    if runs != 2 {
+        t.Fatal(fmt.Sprintf("expect the source to run 2 times, actual: %d", runs))
+    }
+
+    actual = it.Take(3).ToSlice()
+    if !slices.Equal([]int{1, 2, 3}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2, 3}, actual))
+    }
+
+    entries, _ := os.ReadDir(dir)
+    if len(entries) != 1 {
+        t.Fatal(fmt.Sprintf("expect 1 cache file, actual: %d", len(entries)))
+    }
+
+    // a broken file stops the replay with the error, the source is not iterated again
+    name := dir + "/" + entries[0].Name()
+    var buf bytes.Buffer
+    encode := GobCodec[int]().Encoder(&buf)
+    for _, v := range []int{1, 2, 3} {
+        _ = encode(v)
+    }
+    _ = os.WriteFile(name, append(buf.Bytes(), 0xff), 0o600)
+    actual = it.ToSlice()
+    if !slices.Equal([]int{1, 2, 3}, actual) || readErr == nil || runs != 2 {
+        t.Fatal(fmt.Sprintf("expect [1 2 3] with an error and no new run, actual: %v, %v, %d runs", actual, readErr, runs))
+    }
+    _ = os.Remove(name)
+    actual = it.ToSlice()
+    if len(actual) != 0 || !errors.Is(readErr, os.ErrNotExist) || runs != 2 {
+        t.Fatal(fmt.Sprintf("expect no values with os.ErrNotExist and no new run, actual: %v, %v, %d runs", actual, readErr, runs))
+    }
+
+    // the read error can be ignored
+    it, err = CacheToDisk(source, GobCodec[int](), dir, nil)
+    if err != nil {
+        t.Fatal(fmt.Sprintf("expect no error, actual: %v", err))
+    }
+    if actual = it.ToSlice(); !slices.Equal([]int{1, 2, 3, 4, 5}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2, 3, 4, 5}, actual))
+    }
+
+    _, err = CacheToDisk(source, GobCodec[int](), dir+"/not-exist", &readErr)
+    if err == nil {
+        t.Fatal("expect error, actual: nil")
+    }
+}
+
+func TestCacheToDisk_Cleanup(t *testing.T) {
+    dir := t.TempDir()
+    func() {
+        it, err := CacheToDisk(Range(1, 3), GobCodec[int](), dir, nil)
+        if err != nil {
+            t.Fatal(fmt.Sprintf("expect no error, actual: %v", err))
+        }
+        _ = it.ToSlice()
+    }()
+    for i := 0; i < 50; i++ {
+        runtime.GC()
+        if entries, _ := os.ReadDir(dir); len(entries) == 0 {
+            return
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    t.Fatal("expect the cache file to be removed after the iterator is collected")
+}
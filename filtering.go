@@ -1,6 +1,13 @@
 package goiter
 
-import "iter"
+import (
+    "fmt"
+    "hash/maphash"
+    "iter"
+    "math"
+    "math/rand/v2"
+    "reflect"
+)
 
 // Filter returns an iterator that only yields the values of the input iterator that satisfy the predicate.
 func Filter[TIter SeqX[T], T any](
@@ -8,13 +15,7 @@ func Filter[TIter SeqX[T], T any](
     predicate func(T) bool,
 ) Iterator[T] {
     return func(yield func(T) bool) {
-        next, stop := iter.Pull(iter.Seq[T](iterator))
-        defer stop()
-        for {
-            v, ok := next()
-            if !ok {
-                return
-            }
+        for v := range iterator {
             if !predicate(v) {
                 continue
             }
@@ -31,13 +32,7 @@ func Filter2[TIter Seq2X[T1, T2], T1 any, T2 any](
     predicate func(T1, T2) bool,
 ) Iterator2[T1, T2] {
     return func(yield func(T1, T2) bool) {
-        next, stop := iter.Pull2(iter.Seq2[T1, T2](iterator))
-        defer stop()
-        for {
-            v1, v2, ok := next()
-            if !ok {
-                return
-            }
+        for v1, v2 := range iterator {
             if !predicate(v1, v2) {
                 continue
             }
@@ -57,13 +52,7 @@ func OfType[U any, TIter SeqX[T], T any](
     iterator TIter,
 ) Iterator[U] {
     return func(yield func(U) bool) {
-        next, stop := iter.Pull(iter.Seq[T](iterator))
-        defer stop()
-        for {
-            v, ok := next()
-            if !ok {
-                return
-            }
+        for v := range iterator {
             if u, ok := any(v).(U); ok {
                 if !yield(u) {
                     return
@@ -87,14 +76,8 @@ func Take[TIter SeqX[T], T any](
     }
 
     return func(yield func(T) bool) {
-        next, stop := iter.Pull(iter.Seq[T](iterator))
-        defer stop()
         count := 0
-        for {
-            v, ok := next()
-            if !ok {
-                return
-            }
+        for v := range iterator {
             if !yield(v) {
                 return
             }
@@ -116,14 +99,8 @@ func Take2[TIter Seq2X[T1, T2], T1, T2 any](
     }
 
     return func(yield func(T1, T2) bool) {
-        next, stop := iter.Pull2(iter.Seq2[T1, T2](iterator))
-        defer stop()
         count := 0
-        for {
-            v1, v2, ok := next()
-            if !ok {
-                return
-            }
+        for v1, v2 := range iterator {
             if !yield(v1, v2) {
                 return
             }
@@ -140,26 +117,25 @@ func Take2[TIter Seq2X[T1, T2], T1, T2 any](
 //
 // So if an iterator yields 1 2 3 4 5, goiter.Take(iterator, 3) will yield 3 4 5.
 // And if an iterator yields 1 2, goiter.Take(iterator, 3) will yield 1 2.
+// The memory held by the last n values can be limited by WithMemoryBudget, see BufferOption, spilling is not supported.
 func TakeLast[TIter SeqX[T], T any](
     iterator TIter,
     n int,
+    opts ...BufferOption[T],
 ) Iterator[T] {
     if n <= 0 {
         return Empty[T]()
     }
+    if budget := newBufferBudget[T](opts); budget != nil {
+        return Iterator[T](takeLastWithin(iter.Seq[T](iterator), n, budget))
+    }
 
     return func(yield func(T) bool) {
         idxHead := -1
         idxTail := -1
         buffer := make([]T, n)
 
-        next, stop := iter.Pull(iter.Seq[T](iterator))
-        defer stop()
-        for {
-            v, ok := next()
-            if !ok {
-                break
-            }
+        for v := range iterator {
             if idxHead == -1 {
                 buffer[0] = v
                 idxHead = 0
@@ -189,27 +165,60 @@ func TakeLast[TIter SeqX[T], T any](
     }
 }
 
+// takeLastWithin is TakeLast with a memory budget, the sizes of the values in the ring buffer are kept,
+// so the size of the evicted value is released without calling sizeOf again.
+func takeLastWithin[T any](iterator iter.Seq[T], n int, budget *bufferBudget[T]) iter.Seq[T] {
+    return func(yield func(T) bool) {
+        budget.start()
+        var ring []T
+        var sizes []int
+        head := 0
+        used := 0
+        for v := range iterator {
+            size := budget.sizeOf(v)
+            if len(ring) < n {
+                ring = append(ring, v)
+                sizes = append(sizes, size)
+            } else {
+                used -= sizes[head]
+                ring[head] = v
+                sizes[head] = size
+                head = (head + 1) % n
+            }
+            used += size
+            if used > budget.bytes {
+                budget.fail(budget.exceeded())
+                return
+            }
+        }
+        for i := range ring {
+            if !yield(ring[(head+i)%len(ring)]) {
+                return
+            }
+        }
+    }
+}
+
 // TakeLast2 is the iter.Seq2 version of TakeLast function.
+// The memory can be limited by the options of element type *Combined[T1, T2], see BufferOption.
 func TakeLast2[TIter Seq2X[T1, T2], T1, T2 any](
     iterator TIter,
     n int,
+    opts ...BufferOption[*Combined[T1, T2]],
 ) Iterator2[T1, T2] {
     if n <= 0 {
         return Empty2[T1, T2]()
     }
+    if budget := newBufferBudget[*Combined[T1, T2]](opts); budget != nil {
+        return uncombineSeq(takeLastWithin(combineSeq(iter.Seq2[T1, T2](iterator)), n, budget))
+    }
 
     return func(yield func(T1, T2) bool) {
         idxHead := -1
         idxTail := -1
         buffer := make([]*Combined[T1, T2], n)
 
-        next, stop := iter.Pull2(iter.Seq2[T1, T2](iterator))
-        defer stop()
-        for {
-            v1, v2, ok := next()
-            if !ok {
-                break
-            }
+        for v1, v2 := range iterator {
             if idxHead == -1 {
                 buffer[0] = &Combined[T1, T2]{V1: v1, V2: v2}
                 idxHead = 0
@@ -253,14 +262,8 @@ func Skip[TIter SeqX[T], T any](
     }
 
     return func(yield func(T) bool) {
-        next, stop := iter.Pull(iter.Seq[T](iterator))
-        defer stop()
         count := 0
-        for {
-            v, ok := next()
-            if !ok {
-                return
-            }
+        for v := range iterator {
             count++
             if count <= n {
                 continue
@@ -282,14 +285,8 @@ func Skip2[TIter Seq2X[T1, T2], T1, T2 any](
     }
 
     return func(yield func(T1, T2) bool) {
-        next, stop := iter.Pull2(iter.Seq2[T1, T2](iterator))
-        defer stop()
         count := 0
-        for {
-            v1, v2, ok := next()
-            if !ok {
-                return
-            }
+        for v1, v2 := range iterator {
             count++
             if count <= n {
                 continue
@@ -319,13 +316,7 @@ func SkipLast[TIter SeqX[T], T any](
         idxTail := -1
         ringBuff := make([]T, n)
 
-        next, stop := iter.Pull(iter.Seq[T](iterator))
-        defer stop()
-        for {
-            v, ok := next()
-            if !ok {
-                break
-            }
+        for v := range iterator {
             if idxHead == -1 {
                 ringBuff[0] = v
                 idxHead = 0
@@ -360,13 +351,7 @@ func SkipLast2[TIter Seq2X[T1, T2], T1, T2 any](
         idxTail := -1
         ringBuff := make([]*Combined[T1, T2], n)
 
-        next, stop := iter.Pull2(iter.Seq2[T1, T2](iterator))
-        defer stop()
-        for {
-            v1, v2, ok := next()
-            if !ok {
-                break
-            }
+        for v1, v2 := range iterator {
             if idxHead == -1 {
                 ringBuff[0] = &Combined[T1, T2]{V1: v1, V2: v2}
                 idxHead = 0
@@ -393,17 +378,12 @@ func SkipLast2[TIter Seq2X[T1, T2], T1, T2 any](
 //	if the input iterator yields 1 2 3 3 2 1, Distinct function will yield 1 2 3.
 //
 // Note: if this function is used on iterators that has massive amount of data, it might consume a lot of memory.
+// In that case, consider DistinctApprox or DistinctUntilChanged.
 func Distinct[TIter SeqX[T], T comparable](iterator TIter) Iterator[T] {
     return func(yield func(T) bool) {
         yielded := map[any]bool{}
 
-        next, stop := iter.Pull(iter.Seq[T](iterator))
-        defer stop()
-        for {
-            v, ok := next()
-            if !ok {
-                return
-            }
+        for v := range iterator {
             if yielded[v] {
                 continue
             }
@@ -426,13 +406,7 @@ func DistinctV1[TIter Seq2X[T1, T2], T1 comparable, T2 any](iterator TIter) Iter
     return func(yield func(T1, T2) bool) {
         yielded := newDistinctor[T1]()
 
-        next, stop := iter.Pull2(iter.Seq2[T1, T2](iterator))
-        defer stop()
-        for {
-            v1, v2, ok := next()
-            if !ok {
-                return
-            }
+        for v1, v2 := range iterator {
             if !yielded.mark(v1) {
                 continue
             }
@@ -449,13 +423,7 @@ func DistinctV2[TIter Seq2X[T1, T2], T1 any, T2 comparable](iterator TIter) Iter
     return func(yield func(T1, T2) bool) {
         yielded := newDistinctor[T2]()
 
-        next, stop := iter.Pull2(iter.Seq2[T1, T2](iterator))
-        defer stop()
-        for {
-            v1, v2, ok := next()
-            if !ok {
-                return
-            }
+        for v1, v2 := range iterator {
             if !yielded.mark(v2) {
                 continue
             }
@@ -475,13 +443,7 @@ func DistinctBy[TIter SeqX[T], T any, K comparable](
     return func(yield func(T) bool) {
         yielded := newDistinctor[K]()
 
-        next, stop := iter.Pull(iter.Seq[T](iterator))
-        defer stop()
-        for {
-            v, ok := next()
-            if !ok {
-                return
-            }
+        for v := range iterator {
             if !yielded.mark(keySelector(v)) {
                 continue
             }
@@ -501,16 +463,67 @@ func Distinct2By[TIter Seq2X[T1, T2], T1 any, T2 any, K comparable](
     return func(yield func(T1, T2) bool) {
         yielded := newDistinctor[K]()
 
-        next, stop := iter.Pull2(iter.Seq2[T1, T2](iterator))
-        defer stop()
-        for {
-            v1, v2, ok := next()
-            if !ok {
+        for v1, v2 := range iterator {
+            if !yielded.mark(keySelector(v1, v2)) {
+                continue
+            }
+            if !yield(v1, v2) {
                 return
             }
-            if !yielded.mark(keySelector(v1, v2)) {
+        }
+    }
+}
+
+// DistinctUntilChanged returns an iterator that only suppresses consecutive duplicate values of the input iterator.
+// For example:
+//
+//	if the input iterator yields 1 1 2 2 3 1 1, DistinctUntilChanged function will yield 1 2 3 1.
+//
+// Unlike Distinct, it only remembers the last yielded value, so it uses constant memory,
+// and it is a good fit for deduplicating sorted data.
+func DistinctUntilChanged[TIter SeqX[T], T comparable](iterator TIter) Iterator[T] {
+    return DistinctUntilChangedBy(iterator, func(v T) T {
+        return v
+    })
+}
+
+// DistinctUntilChangedBy is like DistinctUntilChanged, but it accepts a custom function to determine the deduplicate-key.
+func DistinctUntilChangedBy[TIter SeqX[T], T any, K comparable](
+    iterator TIter,
+    keySelector func(T) K,
+) Iterator[T] {
+    return func(yield func(T) bool) {
+        var lastKey K
+        first := true
+        for v := range iterator {
+            key := keySelector(v)
+            if !first && key == lastKey {
+                continue
+            }
+            first = false
+            lastKey = key
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+// DistinctUntilChanged2By is the iter.Seq2 version of DistinctUntilChangedBy function.
+func DistinctUntilChanged2By[TIter Seq2X[T1, T2], T1 any, T2 any, K comparable](
+    iterator TIter,
+    keySelector func(T1, T2) K,
+) Iterator2[T1, T2] {
+    return func(yield func(T1, T2) bool) {
+        var lastKey K
+        first := true
+        for v1, v2 := range iterator {
+            key := keySelector(v1, v2)
+            if !first && key == lastKey {
                 continue
             }
+            first = false
+            lastKey = key
             if !yield(v1, v2) {
                 return
             }
@@ -518,6 +531,206 @@ func Distinct2By[TIter Seq2X[T1, T2], T1 any, T2 any, K comparable](
     }
 }
 
+// SeenStore records the values that have been yielded by DistinctPersistent.
+// Implementations may keep the records in memory, in files, or in an embedded database,
+// so that the deduplication state can survive restarts of the program.
+type SeenStore[T any] interface {
+    // Has reports whether the value has been recorded.
+    Has(T) bool
+    // Add records the value.
+    Add(T)
+}
+
+// DistinctPersistent is like Distinct, but the seen values are kept in the provided SeenStore instead of an in-memory map.
+// A value is recorded only after it has been handed over to the consumer, which means if the program crashes while
+// the consumer is processing a value, that value will be yielded again the next time, so it is at-least-once delivery.
+// For example:
+//
+//	if the store has already recorded 2, and the input iterator yields 1 2 3 3 1, DistinctPersistent function will yield 1 3.
+func DistinctPersistent[TIter SeqX[T], T any](iterator TIter, store SeenStore[T]) Iterator[T] {
+    return func(yield func(T) bool) {
+        for v := range iterator {
+            if store.Has(v) {
+                continue
+            }
+            next := yield(v)
+            store.Add(v)
+            if !next {
+                return
+            }
+        }
+    }
+}
+
+// DistinctApprox is like Distinct, but it uses a Bloom filter instead of a map to remember the yielded values,
+// so the memory usage is bounded no matter how many values the input iterator yields.
+// The trade-off is that a value that has never been yielded may be considered as a duplicate and be dropped by mistake,
+// the probability of this is about fpRate as long as the number of distinct values does not exceed expectedN.
+// A value is never yielded twice.
+// If expectedN is less than 1, 1 is used. If fpRate is not in range (0, 1), 0.01 is used.
+func DistinctApprox[TIter SeqX[T], T comparable](
+    iterator TIter,
+    expectedN int,
+    fpRate float64,
+) Iterator[T] {
+    expectedN = max(expectedN, 1)
+    if fpRate <= 0 || fpRate >= 1 {
+        fpRate = 0.01
+    }
+    numBits := uint64(math.Ceil(-float64(expectedN) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+    numBits = max(numBits, 64)
+    // the optimal number of hashes depends only on the false positive rate, it must not be derived from the clamped numBits,
+    // otherwise a tiny expectedN leads to so many hashes that two keys might fully collide.
+    numHashes := max(int(math.Round(-math.Log2(fpRate))), 1)
+
+    return func(yield func(T) bool) {
+        filter := &bloomFilter{
+            bits:      make([]uint64, (numBits+63)/64),
+            numBits:   numBits,
+            numHashes: numHashes,
+            seed1:     maphash.MakeSeed(),
+            seed2:     maphash.MakeSeed(),
+        }
+        var buf []byte
+        for v := range iterator {
+            var hashable bool
+            // a value holding a NaN is not equal to anything, so like Distinct, it is always yielded.
+            if buf, hashable = appendHashKey(buf[:0], v); hashable && !filter.add(buf) {
+                continue
+            }
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+type bloomFilter struct {
+    bits      []uint64
+    numBits   uint64
+    numHashes int
+    seed1     maphash.Seed
+    seed2     maphash.Seed
+}
+
+// add adds the key to the filter, and returns false if the key might have been added before.
+func (f *bloomFilter) add(key []byte) bool {
+    h1 := maphash.Bytes(f.seed1, key)
+    h2 := maphash.Bytes(f.seed2, key) | 1
+    added := false
+    for i := 0; i < f.numHashes; i++ {
+        pos := mix64(h1+uint64(i)*h2) % f.numBits
+        word, mask := pos/64, uint64(1)<<(pos%64)
+        if f.bits[word]&mask == 0 {
+            f.bits[word] |= mask
+            added = true
+        }
+    }
+    return added
+}
+
+// mix64 is the finalizer of splitmix64, it makes every bit of the probe position depend on all bits of the hashes,
+// otherwise with a small numBits the positions are determined by the low bits of h1 and h2 only, and distinct keys collide too often.
+func mix64(x uint64) uint64 {
+    x ^= x >> 30
+    x *= 0xbf58476d1ce4e5b9
+    x ^= x >> 27
+    x *= 0x94d049bb133111eb
+    x ^= x >> 31
+    return x
+}
+
+// appendHashKey appends a byte representation of v to buf, values that are equal by == have the same representation,
+// and values that are not equal have different ones. It returns false if v is not equal to itself, i.e. it holds a NaN.
+func appendHashKey[T comparable](buf []byte, v T) ([]byte, bool) {
+    // the switch is on a pointer, so that it matches only if T is exactly the type, not an interface holding it.
+    switch tv := any(&v).(type) {
+    case *string:
+        return append(buf, *tv...), true
+    case *int:
+        return appendUint64(buf, uint64(*tv)), true
+    case *int64:
+        return appendUint64(buf, uint64(*tv)), true
+    case *uint64:
+        return appendUint64(buf, *tv), true
+    case *float64:
+        return appendFloatKey(buf, *tv)
+    }
+    return appendValueKey(buf, reflect.ValueOf(&v).Elem())
+}
+
+func appendValueKey(buf []byte, v reflect.Value) ([]byte, bool) {
+    switch v.Kind() {
+    case reflect.Bool:
+        if v.Bool() {
+            return append(buf, 1), true
+        }
+        return append(buf, 0), true
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return appendUint64(buf, uint64(v.Int())), true
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+        return appendUint64(buf, v.Uint()), true
+    case reflect.Float32, reflect.Float64:
+        return appendFloatKey(buf, v.Float())
+    case reflect.Complex64, reflect.Complex128:
+        c := v.Complex()
+        buf, ok := appendFloatKey(buf, real(c))
+        if !ok {
+            return buf, false
+        }
+        return appendFloatKey(buf, imag(c))
+    case reflect.String:
+        // the length prefix keeps the boundaries of strings in arrays and structs, so that {"a", "bc"} and {"ab", "c"} differ.
+        s := v.String()
+        return append(appendUint64(buf, uint64(len(s))), s...), true
+    case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+        return appendUint64(buf, uint64(v.Pointer())), true
+    case reflect.Array:
+        ok := true
+        for i := 0; i < v.Len() && ok; i++ {
+            buf, ok = appendValueKey(buf, v.Index(i))
+        }
+        return buf, ok
+    case reflect.Struct:
+        ok := true
+        t := v.Type()
+        for i := 0; i < v.NumField() && ok; i++ {
+            // blank fields are ignored by ==
+            if t.Field(i).Name == "_" {
+                continue
+            }
+            buf, ok = appendValueKey(buf, v.Field(i))
+        }
+        return buf, ok
+    case reflect.Interface:
+        if v.IsNil() {
+            return append(buf, 0), true
+        }
+        // values of different dynamic types are never equal, the type is identified by the address of its descriptor.
+        elem := v.Elem()
+        buf = appendUint64(append(buf, 1), uint64(reflect.ValueOf(elem.Type()).Pointer()))
+        return appendValueKey(buf, elem)
+    default:
+        // like a map key, a dynamic value of an incomparable type can't be hashed
+        panic(fmt.Sprintf("goiter: hash of unhashable type %s", v.Type()))
+    }
+}
+
+// appendFloatKey appends the bits of f, with -0 canonicalised to 0 since they are equal. It returns false if f is NaN.
+func appendFloatKey(buf []byte, f float64) ([]byte, bool) {
+    if f != f {
+        return buf, false
+    }
+    if f == 0 {
+        f = 0
+    }
+    return appendUint64(buf, math.Float64bits(f)), true
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+    return append(buf, byte(v), byte(v>>8), byte(v>>16), byte(v>>24), byte(v>>32), byte(v>>40), byte(v>>48), byte(v>>56))
+}
+
 func newDistinctor[T comparable]() *distinctor[T] {
     return &distinctor[T]{
         dm: map[T]bool{},
@@ -535,3 +748,24 @@ func (d *distinctor[T]) mark(key T) bool {
     }
     return false
 }
+
+// SampleP returns an iterator that passes each value of the input iterator independently with probability p, drawn from r.
+// If r is nil, the global random source is used. If p is not positive, it yields nothing, and if p is not less than 1, it yields all the values.
+// For example:
+//
+//  sampled := goiter.SampleP(spans, 0.01, nil)  // keeps about 1% of the spans
+func SampleP[TIter SeqX[T], T any](iterator TIter, p float64, r *rand.Rand) Iterator[T] {
+    if !(p > 0) {
+        return Empty[T]()
+    }
+    return func(yield func(T) bool) {
+        for v := range iterator {
+            if p < 1 && randFloat64(r) >= p {
+                continue
+            }
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
@@ -3,6 +3,8 @@ package goiter
 import (
     "fmt"
     "maps"
+    "math"
+    "math/rand/v2"
     "slices"
     "testing"
 )
@@ -647,3 +649,181 @@ func TestDistinctBy2(t *testing.T) {
         break
     }
 }
+
+func TestDistinctUntilChanged(t *testing.T) {
+    actual := []int{}
+    for each := range DistinctUntilChanged(SliceElems([]int{1, 1, 2, 2, 3, 1, 1})) {
+        actual = append(actual, each)
+    }
+    expect := []int{1, 2, 3, 1}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []int{}
+    for each := range DistinctUntilChanged(SliceElems([]int{0, 0, 1})) {
+        actual = append(actual, each)
+    }
+    expect = []int{0, 1}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _ = range DistinctUntilChanged(SliceElems([]int{1, 2, 3})) {
+        break
+    }
+}
+
+func TestDistinctUntilChangedBy(t *testing.T) {
+    type student struct {
+        Name string
+        Age  int
+    }
+
+    input := []student{
+        {"john", 20},
+        {"john", 21},
+        {"jane", 18},
+        {"john", 23},
+    }
+    keySelector := func(s student) string { return s.Name }
+    actual := []student{}
+    for each := range DistinctUntilChangedBy(SliceElems(input), keySelector) {
+        actual = append(actual, each)
+    }
+    expect := []student{
+        {"john", 20},
+        {"jane", 18},
+        {"john", 23},
+    }
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _ = range DistinctUntilChangedBy(SliceElems(input), keySelector) {
+        break
+    }
+}
+
+func TestDistinctUntilChanged2By(t *testing.T) {
+    input := []int{1, 1, 2, 3, 3, 3, 1}
+    keySelector := func(_ int, v int) int { return v }
+    actual := []int{}
+    for idx := range DistinctUntilChanged2By(Slice(input), keySelector) {
+        actual = append(actual, idx)
+    }
+    expect := []int{0, 2, 3, 6}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _, _ = range DistinctUntilChanged2By(Slice(input), keySelector) {
+        break
+    }
+}
+
+type testSeenStore map[int]bool
+
+func (s testSeenStore) Has(v int) bool {
+    return s[v]
+}
+
+func (s testSeenStore) Add(v int) {
+    s[v] = true
+}
+
+func TestDistinctPersistent(t *testing.T) {
+    store := testSeenStore{2: true}
+    actual := []int{}
+    for each := range DistinctPersistent(SliceElems([]int{1, 2, 3, 3, 1}), store) {
+        actual = append(actual, each)
+    }
+    expect := []int{1, 3}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    // the state is kept in the store, so a new run only yields unseen values
+    actual = []int{}
+    for each := range DistinctPersistent(SliceElems([]int{1, 4, 5, 2}), store) {
+        actual = append(actual, each)
+        if each == 4 {
+            break
+        }
+    }
+    expect = []int{4}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+    if !store[4] || store[5] {
+        t.Fatal(fmt.Sprintf("unexpected store state: %v", store))
+    }
+}
+
+func TestDistinctApprox(t *testing.T) {
+    actual := []int{}
+    for each := range DistinctApprox(SliceElems([]int{1, 2, 3, 3, 2, 1, 4}), 100, 0.001) {
+        actual = append(actual, each)
+    }
+    expect := []int{1, 2, 3, 4}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    // the false positive rate is roughly respected
+    count := DistinctApprox(Range(1, 10000), 10000, 0.01).Count()
+    if count < 9800 {
+        t.Fatal(fmt.Sprintf("too many false positives, count: %d", count))
+    }
+
+    // values are never yielded twice
+    type point struct{ X, Y int }
+    points := []point{{1, 2}, {2, 1}, {1, 2}}
+    actualPoints := []point{}
+    for each := range DistinctApprox(SliceElems(points), 0, 2) {
+        actualPoints = append(actualPoints, each)
+    }
+    expectPoints := []point{{1, 2}, {2, 1}}
+    if !slices.Equal(expectPoints, actualPoints) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expectPoints, actualPoints))
+    }
+
+    // keys agree with ==: 0 and -0 are equal, NaN is never equal, and pointers are compared by address
+    floats := DistinctApprox(Items(0.0, math.Copysign(0, -1), math.NaN(), math.NaN()), 10, 0.01).Count()
+    if floats != 3 {
+        t.Fatal(fmt.Sprintf("expect: 3, actual: %d", floats))
+    }
+    a, b := &point{1, 2}, &point{1, 2}
+    if n := DistinctApprox(Items(a, b, a), 10, 0.01).Count(); n != 2 {
+        t.Fatal(fmt.Sprintf("expect: 2, actual: %d", n))
+    }
+    anys := DistinctApprox(Items[any](1, int64(1), "1", 1, nil, nil), 10, 0.01).ToSlice()
+    if len(anys) != 4 {
+        t.Fatal(fmt.Sprintf("expect 4 values, actual: %v", anys))
+    }
+
+    for _ = range DistinctApprox(Items("a", "b"), 10, 0.01) {
+        break
+    }
+}
+
+func TestSampleP(t *testing.T) {
+    n := Count(SampleP(Range(1, 10000), 0.1, rand.New(rand.NewPCG(1, 2))))
+    if n < 800 || n > 1200 {
+        t.Fatal(fmt.Sprintf("expect about 1000 values, actual: %d", n))
+    }
+
+    first := SampleP(Range(1, 100), 0.5, rand.New(rand.NewPCG(3, 4))).ToSlice()
+    second := SampleP(Range(1, 100), 0.5, rand.New(rand.NewPCG(3, 4))).ToSlice()
+    if !slices.Equal(first, second) {
+        t.Fatal("expect the same sample from the same seed")
+    }
+
+    if Count(SampleP(Range(1, 10), 1, nil)) != 10 || Count(SampleP(Range(1, 10), 0, nil)) != 0 {
+        t.Fatal("expect all values for p = 1 and nothing for p = 0")
+    }
+
+    for _ = range SampleP(Range(1, 10), 1, nil) {
+        break
+    }
+}
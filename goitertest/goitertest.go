@@ -0,0 +1,109 @@
+// Package goitertest provides helpers for testing the code that produces goiter iterators, or any iter.Seq and iter.Seq2.
+package goitertest
+
+import (
+    "slices"
+    "testing"
+
+    "github.com/hsldymq/goiter"
+)
+
+// AssertYields checks that the iterator yields exactly the expected values in order.
+func AssertYields[TIter goiter.SeqX[T], T comparable](t testing.TB, iterator TIter, expected []T) {
+    t.Helper()
+    actual := make([]T, 0, len(expected))
+    for v := range iterator {
+        actual = append(actual, v)
+    }
+    if !slices.Equal(expected, actual) {
+        t.Errorf("expect: %v, actual: %v", expected, actual)
+    }
+}
+
+// AssertYields2 checks that the iterator yields exactly the expected pairs in order,
+// the first elements of the pairs are compared with expected1 and the second elements with expected2.
+func AssertYields2[TIter goiter.Seq2X[T1, T2], T1, T2 comparable](t testing.TB, iterator TIter, expected1 []T1, expected2 []T2) {
+    t.Helper()
+    actual1 := make([]T1, 0, len(expected1))
+    actual2 := make([]T2, 0, len(expected2))
+    for v1, v2 := range iterator {
+        actual1 = append(actual1, v1)
+        actual2 = append(actual2, v2)
+    }
+    if !slices.Equal(expected1, actual1) || !slices.Equal(expected2, actual2) {
+        t.Errorf("expect: %v %v, actual: %v %v", expected1, expected2, actual1, actual2)
+    }
+}
+
+// AssertBreaks checks that the iterator stops correctly when the consumer breaks out of the loop after n values:
+// it must not call yield again after yield returns false, and it must yield at least n values.
+func AssertBreaks[TIter goiter.SeqX[T], T any](t testing.TB, iterator TIter, n int) {
+    t.Helper()
+    count := 0
+    stopped := false
+    extraCalls := 0
+    iterator(func(T) bool {
+        if stopped {
+            extraCalls++
+            return false
+        }
+        count++
+        if count >= n {
+            stopped = true
+            return false
+        }
+        return true
+    })
+    if count < n {
+        t.Errorf("expect at least %d values before breaking, actual: %d", n, count)
+    }
+    if extraCalls > 0 {
+        t.Errorf("expect no values after breaking, actual: %d more values", extraCalls)
+    }
+}
+
+// AssertBreaks2 is the iter.Seq2 version of AssertBreaks.
+func AssertBreaks2[TIter goiter.Seq2X[T1, T2], T1, T2 any](t testing.TB, iterator TIter, n int) {
+    t.Helper()
+    count := 0
+    stopped := false
+    extraCalls := 0
+    iterator(func(T1, T2) bool {
+        if stopped {
+            extraCalls++
+            return false
+        }
+        count++
+        if count >= n {
+            stopped = true
+            return false
+        }
+        return true
+    })
+    if count < n {
+        t.Errorf("expect at least %d values before breaking, actual: %d", n, count)
+    }
+    if extraCalls > 0 {
+        t.Errorf("expect no values after breaking, actual: %d more values", extraCalls)
+    }
+}
+
+// AssertSingleUse checks that the iterator yields values on the first iteration, and yields nothing on the second one,
+// which is the semantics of the single-use iterators such as the ones created by goiter.Once.
+func AssertSingleUse[TIter goiter.SeqX[T], T any](t testing.TB, iterator TIter) {
+    t.Helper()
+    first := 0
+    for range iterator {
+        first++
+    }
+    second := 0
+    for range iterator {
+        second++
+    }
+    if first == 0 {
+        t.Errorf("expect values on the first iteration, actual: nothing")
+    }
+    if second != 0 {
+        t.Errorf("expect nothing on the second iteration, actual: %d values", second)
+    }
+}
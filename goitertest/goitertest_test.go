@@ -0,0 +1,98 @@
+package goitertest
+
+import (
+    "fmt"
+    "testing"
+
+    "github.com/hsldymq/goiter"
+)
+
+// recorder is a testing.TB that records the failures instead of failing the test.
+type recorder struct {
+    testing.TB
+    failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+    r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertYields(t *testing.T) {
+    r := &recorder{TB: t}
+    AssertYields(r, goiter.Range(1, 3), []int{1, 2, 3})
+    if len(r.failures) != 0 {
+        t.Fatal(fmt.Sprintf("expect no failures, actual: %v", r.failures))
+    }
+
+    AssertYields(r, goiter.Range(1, 3), []int{1, 2})
+    if len(r.failures) != 1 {
+        t.Fatal(fmt.Sprintf("expect 1 failure, actual: %v", r.failures))
+    }
+}
+
+func TestAssertYields2(t *testing.T) {
+    r := &recorder{TB: t}
+    AssertYields2(r, goiter.Slice([]string{"a", "b"}), []int{0, 1}, []string{"a", "b"})
+    if len(r.failures) != 0 {
+        t.Fatal(fmt.Sprintf("expect no failures, actual: %v", r.failures))
+    }
+
+    AssertYields2(r, goiter.Slice([]string{"a", "b"}), []int{0, 1}, []string{"a", "c"})
+    if len(r.failures) != 1 {
+        t.Fatal(fmt.Sprintf("expect 1 failure, actual: %v", r.failures))
+    }
+}
+
+func TestAssertBreaks(t *testing.T) {
+    r := &recorder{TB: t}
+    AssertBreaks(r, goiter.Counter(0), 3)
+    if len(r.failures) != 0 {
+        t.Fatal(fmt.Sprintf("expect no failures, actual: %v", r.failures))
+    }
+
+    ignoresBreak := func(yield func(int) bool) {
+        for i := 0; i < 5; i++ {
+            yield(i)
+        }
+    }
+    AssertBreaks(r, ignoresBreak, 2)
+    AssertBreaks(r, goiter.Range(1, 2), 3)
+    if len(r.failures) != 2 {
+        t.Fatal(fmt.Sprintf("expect 2 failures, actual: %v", r.failures))
+    }
+}
+
+func TestAssertBreaks2(t *testing.T) {
+    r := &recorder{TB: t}
+    AssertBreaks2(r, goiter.Slice([]int{1, 2, 3}), 2)
+    if len(r.failures) != 0 {
+        t.Fatal(fmt.Sprintf("expect no failures, actual: %v", r.failures))
+    }
+
+    ignoresBreak := func(yield func(int, string) bool) {
+        for i := 0; i < 5; i++ {
+            yield(i, "")
+        }
+    }
+    AssertBreaks2(r, ignoresBreak, 2)
+    AssertBreaks2(r, goiter.Slice([]int{1}), 3)
+    if len(r.failures) != 2 {
+        t.Fatal(fmt.Sprintf("expect 2 failures, actual: %v", r.failures))
+    }
+}
+
+func TestAssertSingleUse(t *testing.T) {
+    r := &recorder{TB: t}
+    AssertSingleUse(r, goiter.Once(goiter.Range(1, 3)))
+    if len(r.failures) != 0 {
+        t.Fatal(fmt.Sprintf("expect no failures, actual: %v", r.failures))
+    }
+
+    AssertSingleUse(r, goiter.Range(1, 3))
+    AssertSingleUse(r, goiter.Empty[int]())
+    if len(r.failures) != 2 {
+        t.Fatal(fmt.Sprintf("expect 2 failures, actual: %v", r.failures))
+    }
+}
@@ -0,0 +1,152 @@
+package goiter
+
+import (
+    "bytes"
+    "net/http"
+    "strings"
+)
+
+// StreamFormat is the wire format of the responses of StreamHandler.
+type StreamFormat int
+
+const (
+    // StreamNDJSON writes each element as it is encoded by the codec, which is expected to end each element with a newline.
+    StreamNDJSON StreamFormat = iota
+    // StreamSSE writes each element as a server-sent event, whose data is the encoded element.
+    StreamSSE
+)
+
+// StreamOption configures StreamHandler.
+type StreamOption func(*streamConfig)
+
+type streamConfig struct {
+    errorMapper func(err error) (int, string)
+}
+
+// WithStreamErrorMapper sets the function that turns an error yielded by the iterator into the status code and the message sent to the client.
+// The status code is only used if nothing has been written yet. By default, the status is 500 and the message is its status text,
+// so the error message, which might contain internal details, is not exposed to the client.
+// For example:
+//
+//  goiter.WithStreamErrorMapper(func(err error) (int, string) {
+//      if errors.Is(err, ErrInvalidQuery) {
+//          return http.StatusBadRequest, err.Error()
+//      }
+//      log.Println(err)
+//      return http.StatusInternalServerError, "internal error"
+//  })
+func WithStreamErrorMapper(mapper func(err error) (status int, message string)) StreamOption {
+    return func(c *streamConfig) {
+        c.errorMapper = mapper
+    }
+}
+
+// StreamHandler returns an http.Handler that streams the elements of the iterator created by build as the response body.
+// Each element is encoded by codec, if codec is nil, JSONCodec is used, and the response is flushed after each element.
+// If the iterator yields an error before anything is written, the handler responds with the status and the message
+// mapped from the error by WithStreamErrorMapper, which is status 500 and its status text by default.
+// If it happens later, the status can no longer be changed, so in StreamSSE format, an "error" event with the message is written to end the stream,
+// and in StreamNDJSON format, the handler panics with http.ErrAbortHandler, which makes the server abort the response,
+// so the client sees a truncated response instead of a successful one.
+// The iteration stops when the client disconnects, the request context is also available to build through the request.
+// For example:
+//
+//  http.Handle("/users", goiter.StreamHandler(func(r *http.Request) goiter.Iterator2[User, error] {
+//      return queryUsers(r.Context(), r.URL.Query().Get("name"))
+//  }, nil, goiter.StreamNDJSON))
+func StreamHandler[T any](build func(r *http.Request) Iterator2[T, error], codec Codec[T], format StreamFormat, opts ...StreamOption) http.Handler {
+    if codec == nil {
+        codec = JSONCodec[T]()
+    }
+    config := &streamConfig{
+        errorMapper: func(error) (int, string) {
+            return http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+        },
+    }
+    for _, opt := range opts {
+        opt(config)
+    }
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        ctx := r.Context()
+        flusher, _ := w.(http.Flusher)
+        buf := &bytes.Buffer{}
+        encode := codec.Encoder(buf)
+        started := false
+
+        start := func() {
+            if started {
+                return
+            }
+            started = true
+            if format == StreamSSE {
+                w.Header().Set("Content-Type", "text/event-stream")
+                w.Header().Set("Cache-Control", "no-cache")
+            } else {
+                w.Header().Set("Content-Type", "application/x-ndjson")
+            }
+            w.WriteHeader(http.StatusOK)
+        }
+        fail := func(err error) {
+            status, message := config.errorMapper(err)
+            if !started {
+                http.Error(w, message, status)
+                return
+            }
+            if format == StreamSSE {
+                writeSSE(w, "error", []byte(message))
+                if flusher != nil {
+                    flusher.Flush()
+                }
+                return
+            }
+            panic(http.ErrAbortHandler)
+        }
+
+        for v, err := range build(r) {
+            if ctx.Err() != nil {
+                return
+            }
+            if err != nil {
+                fail(err)
+                return
+            }
+            buf.Reset()
+            if err := encode(v); err != nil {
+                fail(err)
+                return
+            }
+            start()
+            var writeErr error
+            if format == StreamSSE {
+                writeErr = writeSSE(w, "", buf.Bytes())
+            } else {
+                _, writeErr = w.Write(buf.Bytes())
+            }
+            if writeErr != nil {
+                return
+            }
+            if flusher != nil {
+                flusher.Flush()
+            }
+        }
+        start()
+    })
+}
+
+// writeSSE writes data as a server-sent event, each line of data is written as a separate data field.
+func writeSSE(w http.ResponseWriter, event string, data []byte) error {
+    var sb strings.Builder
+    if event != "" {
+        sb.WriteString("event: ")
+        sb.WriteString(event)
+        sb.WriteString("\n")
+    }
+    for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+        sb.WriteString("data: ")
+        sb.WriteString(line)
+        sb.WriteString("\n")
+    }
+    sb.WriteString("\n")
+    _, err := w.Write([]byte(sb.String()))
+    return err
+}
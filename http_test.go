@@ -0,0 +1,121 @@
+package goiter
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+)
+
+func TestStreamHandler(t *testing.T) {
+    type item struct {
+        N int `json:"n"`
+    }
+    build := func(r *http.Request) Iterator2[item, error] {
+        return func(yield func(item, error) bool) {
+            for i := 1; i <= 2; i++ {
+                if !yield(item{N: i}, nil) {
+                    return
+                }
+            }
+            if r.URL.Query().Get("fail") != "" {
+                yield(item{}, errors.New("boom"))
+            }
+        }
+    }
+
+    rec := httptest.NewRecorder()
+    StreamHandler(build, nil, StreamNDJSON).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+    expect := "{\"n\":1}\n{\"n\":2}\n"
+    if rec.Code != 200 || rec.Body.String() != expect || rec.Header().Get("Content-Type") != "application/x-ndjson" || !rec.Flushed {
+        t.Fatal(fmt.Sprintf("expect: %q, actual: %d %q", expect, rec.Code, rec.Body.String()))
+    }
+
+    rec = httptest.NewRecorder()
+    StreamHandler(build, JSONCodec[item](), StreamSSE).ServeHTTP(rec, httptest.NewRequest("GET", "/?fail=1", nil))
+    expect = "data: {\"n\":1}\n\ndata: {\"n\":2}\n\nevent: error\ndata: Internal Server Error\n\n"
+    if rec.Code != 200 || rec.Body.String() != expect || rec.Header().Get("Content-Type") != "text/event-stream" {
+        t.Fatal(fmt.Sprintf("expect: %q, actual: %d %q", expect, rec.Code, rec.Body.String()))
+    }
+
+    // a failure in the middle of a NDJSON stream aborts the response
+    func() {
+        defer func() {
+            if r := recover(); r != http.ErrAbortHandler {
+                t.Fatal(fmt.Sprintf("expect panic with http.ErrAbortHandler, actual: %v", r))
+            }
+        }()
+        StreamHandler(build, nil, StreamNDJSON).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/?fail=1", nil))
+    }()
+    server := httptest.NewServer(StreamHandler(build, nil, StreamNDJSON))
+    defer server.Close()
+    resp, err := http.Get(server.URL + "/?fail=1")
+    if err != nil {
+        t.Fatal(fmt.Sprintf("unexpected error: %v", err))
+    }
+    body, err := io.ReadAll(resp.Body)
+    resp.Body.Close()
+    if err == nil {
+        t.Fatal(fmt.Sprintf("expect the client to see a truncated response, actual: %q", body))
+    }
+
+    rec = httptest.NewRecorder()
+    StreamHandler(func(r *http.Request) Iterator2[item, error] {
+        return func(yield func(item, error) bool) {
+            yield(item{}, errors.New("boom"))
+        }
+    }, nil, StreamNDJSON).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+    if rec.Code != 500 || strings.Contains(rec.Body.String(), "boom") {
+        t.Fatal(fmt.Sprintf("expect status 500 without the error message, actual: %d %q", rec.Code, rec.Body.String()))
+    }
+
+    mapper := WithStreamErrorMapper(func(err error) (int, string) {
+        return http.StatusBadRequest, "bad: " + err.Error()
+    })
+    rec = httptest.NewRecorder()
+    StreamHandler(func(r *http.Request) Iterator2[item, error] {
+        return func(yield func(item, error) bool) {
+            yield(item{}, errors.New("boom"))
+        }
+    }, nil, StreamNDJSON, mapper).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+    if rec.Code != 400 || rec.Body.String() != "bad: boom\n" {
+        t.Fatal(fmt.Sprintf("expect 400 \"bad: boom\", actual: %d %q", rec.Code, rec.Body.String()))
+    }
+    rec = httptest.NewRecorder()
+    StreamHandler(build, nil, StreamSSE, mapper).ServeHTTP(rec, httptest.NewRequest("GET", "/?fail=1", nil))
+    if !strings.HasSuffix(rec.Body.String(), "event: error\ndata: bad: boom\n\n") {
+        t.Fatal(fmt.Sprintf("expect the mapped message in the error event, actual: %q", rec.Body.String()))
+    }
+
+    rec = httptest.NewRecorder()
+    StreamHandler(func(r *http.Request) Iterator2[item, error] {
+        return Empty2[item, error]()
+    }, nil, StreamNDJSON).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+    if rec.Code != 200 || rec.Body.Len() != 0 {
+        t.Fatal(fmt.Sprintf("expect an empty 200 response, actual: %d %q", rec.Code, rec.Body.String()))
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    produced := 0
+    rec = httptest.NewRecorder()
+    StreamHandler(func(r *http.Request) Iterator2[item, error] {
+        return func(yield func(item, error) bool) {
+            for i := 0; ; i++ {
+                produced++
+                if i == 3 {
+                    cancel()
+                }
+                if !yield(item{N: i}, nil) {
+                    return
+                }
+            }
+        }
+    }, nil, StreamNDJSON).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil).WithContext(ctx))
+    if produced != 4 {
+        t.Fatal(fmt.Sprintf("expect the stream to stop after the client disconnects, actual: %d elements produced", produced))
+    }
+}
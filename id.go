@@ -0,0 +1,187 @@
+package goiter
+
+import (
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/binary"
+    "encoding/hex"
+    "sync"
+    "time"
+)
+
+// UUIDv4 returns an infinite iterator that yields random (version 4) UUIDs in their canonical string form,
+// such as "f47ac10b-58cc-4372-a567-0e02b2c3d479".
+func UUIDv4() Iterator[string] {
+    return Sequence(func() (string, bool) {
+        var u [16]byte
+        readRandom(u[:])
+        u[6] = (u[6] & 0x0f) | 0x40
+        u[8] = (u[8] & 0x3f) | 0x80
+
+        var buf [36]byte
+        hex.Encode(buf[0:8], u[0:4])
+        buf[8] = '-'
+        hex.Encode(buf[9:13], u[4:6])
+        buf[13] = '-'
+        hex.Encode(buf[14:18], u[6:8])
+        buf[18] = '-'
+        hex.Encode(buf[19:23], u[8:10])
+        buf[23] = '-'
+        hex.Encode(buf[24:], u[10:])
+        return string(buf[:]), true
+    })
+}
+
+// ULIDs returns an infinite iterator that yields ULIDs (Universally Unique Lexicographically Sortable Identifier)
+// in their canonical 26 characters Crockford's base32 form.
+// The timestamp part is taken from the clock function, if clock is nil, time.Now is used.
+// ULIDs generated by the same iterator within the same millisecond are monotonically increasing,
+// so the yielded values are always in ascending order as long as the clock does not go backwards.
+// As the ULID specification requires, if the 80-bit random part overflows within one millisecond, which is practically impossible
+// unless the clock is stuck, the iteration stops instead of wrapping around to a smaller ULID.
+func ULIDs(clock func() time.Time) Iterator[string] {
+    if clock == nil {
+        clock = time.Now
+    }
+
+    mu := &sync.Mutex{}
+    var lastMs uint64
+    var entropy [10]byte
+    return Sequence(func() (string, bool) {
+        mu.Lock()
+        defer mu.Unlock()
+
+        ms := uint64(clock().UnixMilli())
+        if ms == lastMs {
+            if !incrementEntropy(&entropy) {
+                return "", false
+            }
+        } else {
+            lastMs = ms
+            readRandom(entropy[:])
+        }
+
+        var id [16]byte
+        id[0] = byte(ms >> 40)
+        id[1] = byte(ms >> 32)
+        id[2] = byte(ms >> 24)
+        id[3] = byte(ms >> 16)
+        id[4] = byte(ms >> 8)
+        id[5] = byte(ms)
+        copy(id[6:], entropy[:])
+        return encodeULID(id), true
+    })
+}
+
+// SnowflakeEpoch is the custom epoch used by Snowflake, which is the same as Twitter's, in milliseconds.
+const SnowflakeEpoch int64 = 1288834974657
+
+// Snowflake returns an infinite iterator that yields Snowflake IDs.
+// Each ID is a 63-bit integer composed of a 41-bit millisecond timestamp since SnowflakeEpoch, the 10-bit nodeID and a 12-bit sequence number.
+// So IDs yielded by the same iterator are always increasing, and IDs from different nodes never collide.
+// If more than 4096 IDs are requested within one millisecond, it waits for the next millisecond.
+// The nodeID must be in range [0, 1023], otherwise it yields nothing.
+func Snowflake(nodeID int64) Iterator[int64] {
+    if nodeID < 0 || nodeID > 1023 {
+        return Empty[int64]()
+    }
+
+    mu := &sync.Mutex{}
+    var lastMs int64
+    var seq int64
+    return Sequence(func() (int64, bool) {
+        mu.Lock()
+        defer mu.Unlock()
+
+        ms := time.Now().UnixMilli() - SnowflakeEpoch
+        if ms < lastMs {
+            ms = lastMs
+        }
+        if ms == lastMs {
+            seq = (seq + 1) & 0xfff
+            if seq == 0 {
+                for ms <= lastMs {
+                    time.Sleep(100 * time.Microsecond)
+                    ms = time.Now().UnixMilli() - SnowflakeEpoch
+                }
+            }
+        } else {
+            seq = 0
+        }
+        lastMs = ms
+        return ms<<22 | nodeID<<12 | seq, true
+    })
+}
+
+// AssignIDs returns an iterator that pairs each value of the input iterator with an ID generated by next.
+// It is like WithCounter, but the ID generation is pluggable.
+// For example:
+//
+//  ids, stop := iter.Pull(goiter.UUIDv4())
+//  defer stop()
+//  iterator := goiter.AssignIDs(events, func() string {
+//      id, _ := ids()
+//      return id
+//  })  // iterator yields (uuid, event) pairs
+func AssignIDs[TIter SeqX[T], T any, ID any](iterator TIter, next func() ID) Iterator2[ID, T] {
+    return func(yield func(ID, T) bool) {
+        for v := range iterator {
+            if !yield(next(), v) {
+                return
+            }
+        }
+    }
+}
+
+// ContentID returns an iterator that pairs each value of the input iterator with a deterministic ID derived from its content.
+// The ID is the hex encoded SHA-256 hash of the bytes returned by content, so equal contents always get the same ID,
+// and the same stream gets the same IDs on every run.
+// For example:
+//
+//  iterator := goiter.ContentID(docs, func(d Doc) []byte {
+//      return []byte(d.Body)
+//  })
+func ContentID[TIter SeqX[T], T any](iterator TIter, content func(T) []byte) Iterator2[string, T] {
+    return func(yield func(string, T) bool) {
+        for v := range iterator {
+            sum := sha256.Sum256(content(v))
+            if !yield(hex.EncodeToString(sum[:]), v) {
+                return
+            }
+        }
+    }
+}
+
+func readRandom(b []byte) {
+    if _, err := rand.Read(b); err != nil {
+        panic(err)
+    }
+}
+
+// incrementEntropy increments the entropy as an 80-bit big-endian integer to keep monotonicity,
+// it returns false and leaves the entropy unchanged if it would overflow.
+func incrementEntropy(entropy *[10]byte) bool {
+    for i := len(entropy) - 1; i >= 0; i-- {
+        if entropy[i] != 0xff {
+            entropy[i]++
+            clear(entropy[i+1:])
+            return true
+        }
+    }
+    return false
+}
+
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func encodeULID(id [16]byte) string {
+    // 128 bits are encoded into 26 characters, 5 bits each, with 2 leading zero bits
+    hi := binary.BigEndian.Uint64(id[:8])
+    lo := binary.BigEndian.Uint64(id[8:])
+    var buf [26]byte
+    for i := 25; i >= 0; i-- {
+        buf[i] = crockfordBase32[lo&0x1f]
+        lo = lo>>5 | hi<<59
+        hi >>= 5
+    }
+    return string(buf[:])
+}
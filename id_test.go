@@ -0,0 +1,120 @@
+package goiter
+
+import (
+    "fmt"
+    "regexp"
+    "slices"
+    "testing"
+    "time"
+)
+
+func TestUUIDv4(t *testing.T) {
+    pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+    seen := map[string]bool{}
+    for each := range UUIDv4().Take(100) {
+        if !pattern.MatchString(each) {
+            t.Fatal(fmt.Sprintf("invalid uuid: %s", each))
+        }
+        if seen[each] {
+            t.Fatal(fmt.Sprintf("duplicate uuid: %s", each))
+        }
+        seen[each] = true
+    }
+}
+
+func TestULIDs(t *testing.T) {
+    now := time.UnixMilli(1469918176385)
+    clock := func() time.Time { return now }
+
+    actual := []string{}
+    for each := range ULIDs(clock).Take(100) {
+        actual = append(actual, each)
+    }
+    for _, each := range actual {
+        if len(each) != 26 || each[:10] != "01ARYZ6S41" {
+            t.Fatal(fmt.Sprintf("invalid ulid: %s", each))
+        }
+    }
+    if !slices.IsSorted(actual) || len(slices.Compact(slices.Clone(actual))) != len(actual) {
+        t.Fatal(fmt.Sprintf("expect strictly increasing ulids, actual: %v", actual))
+    }
+
+    iterator := ULIDs(nil)
+    var prev string
+    for each := range iterator.Take(10) {
+        if each <= prev {
+            t.Fatal(fmt.Sprintf("expect %s to be greater than %s", each, prev))
+        }
+        prev = each
+    }
+}
+
+func TestIncrementEntropy(t *testing.T) {
+    entropy := [10]byte{0, 0, 0, 0, 0, 0, 0, 0, 0x01, 0xff}
+    if !incrementEntropy(&entropy) || entropy != [10]byte{0, 0, 0, 0, 0, 0, 0, 0, 0x02, 0x00} {
+        t.Fatal(fmt.Sprintf("unexpected entropy: %x", entropy))
+    }
+
+    full := [10]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+    entropy = full
+    if incrementEntropy(&entropy) || entropy != full {
+        t.Fatal(fmt.Sprintf("expect the overflow to be refused, actual: %x", entropy))
+    }
+}
+
+func TestSnowflake(t *testing.T) {
+    var prev int64
+    for each := range Snowflake(5).Take(10000) {
+        if each <= prev {
+            t.Fatal(fmt.Sprintf("expect %d to be greater than %d", each, prev))
+        }
+        if (each>>12)&0x3ff != 5 {
+            t.Fatal(fmt.Sprintf("unexpected node id in %d", each))
+        }
+        prev = each
+    }
+
+    count := Snowflake(1024).Count()
+    if count != 0 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", 0, count))
+    }
+}
+
+func TestAssignIDs(t *testing.T) {
+    next := 100
+    actual := []string{}
+    iterator := AssignIDs(Items("a", "b", "c"), func() int {
+        next++
+        return next
+    })
+    for id, v := range iterator {
+        actual = append(actual, fmt.Sprintf("%d-%s", id, v))
+    }
+    expect := []string{"101-a", "102-b", "103-c"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _, _ = range iterator {
+        break
+    }
+}
+
+func TestContentID(t *testing.T) {
+    content := func(s string) []byte { return []byte(s) }
+    ids := []string{}
+    for id, _ := range ContentID(Items("a", "b", "a"), content) {
+        ids = append(ids, id)
+    }
+    if len(ids) != 3 || ids[0] != ids[2] || ids[0] == ids[1] {
+        t.Fatal(fmt.Sprintf("expect equal IDs for equal contents, actual: %v", ids))
+    }
+    expect := "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"
+    if ids[0] != expect {
+        t.Fatal(fmt.Sprintf("expect: %s, actual: %s", expect, ids[0]))
+    }
+
+    for _, _ = range ContentID(Items("a", "b"), content) {
+        break
+    }
+}
@@ -1,6 +1,7 @@
 package goiter
 
 import (
+    "io"
     "iter"
 )
 
@@ -18,12 +19,16 @@ func (it Iterator[T]) WithCounter(startFrom int) Iterator2[int, T] {
     return Zip(Counter(startFrom), it)
 }
 
-func (it Iterator[T]) OrderBy(cmp func(T, T) int) Iterator[T] {
-    return OrderBy(it, cmp)
+func (it Iterator[T]) Enumerate(start, step int) Iterator2[int, T] {
+    return Enumerate(it, start, step)
 }
 
-func (it Iterator[T]) StableOrderBy(cmp func(T, T) int) Iterator[T] {
-    return StableOrderBy(it, cmp)
+func (it Iterator[T]) OrderBy(cmp func(T, T) int, opts ...BufferOption[T]) Iterator[T] {
+    return OrderBy(it, cmp, opts...)
+}
+
+func (it Iterator[T]) StableOrderBy(cmp func(T, T) int, opts ...BufferOption[T]) Iterator[T] {
+    return StableOrderBy(it, cmp, opts...)
 }
 
 func (it Iterator[T]) Filter(predicate func(T) bool) Iterator[T] {
@@ -34,8 +39,8 @@ func (it Iterator[T]) Take(n int) Iterator[T] {
     return Take(it, n)
 }
 
-func (it Iterator[T]) TakeLast(n int) Iterator[T] {
-    return TakeLast(it, n)
+func (it Iterator[T]) TakeLast(n int, opts ...BufferOption[T]) Iterator[T] {
+    return TakeLast(it, n, opts...)
 }
 
 func (it Iterator[T]) Skip(n int) Iterator[T] {
@@ -50,8 +55,20 @@ func (it Iterator[T]) Concat(its ...Iterator[T]) Iterator[T] {
     return Concat(it, its...)
 }
 
-func (it Iterator[T]) Reverse() Iterator[T] {
-    return Reverse(it)
+func (it Iterator[T]) PadEnd(minLen int, fill T) Iterator[T] {
+    return PadEnd(it, minLen, fill)
+}
+
+// Zip zips it with other, see the Zip function. Since a method can't have its own type parameters, other must have the same
+// element type as it. To zip iterators of different element types, use the Zip function, whose result can be chained as well:
+//
+//  goiter.Zip(names, goiter.Counter(1)).Filter(...)
+func (it Iterator[T]) Zip(other Iterator[T]) Iterator2[T, T] {
+    return Zip(it, other)
+}
+
+func (it Iterator[T]) Reverse(opts ...BufferOption[T]) Iterator[T] {
+    return Reverse(it, opts...)
 }
 
 func (it Iterator[T]) Count() int {
@@ -62,8 +79,12 @@ func (it Iterator[T]) Through(f func(T) T) Iterator[T] {
     return Transform(it, f)
 }
 
-func (it Iterator[T]) Cache() Iterator[T] {
-    return Cache(it)
+func (it Iterator[T]) Cache(opts ...BufferOption[T]) Iterator[T] {
+    return Cache(it, opts...)
+}
+
+func (it Iterator[T]) CacheN(n int) Iterator[T] {
+    return CacheN(it, n)
 }
 
 func (it Iterator[T]) Once() Iterator[T] {
@@ -73,3 +94,23 @@ func (it Iterator[T]) Once() Iterator[T] {
 func (it Iterator[T]) FinishOnce() Iterator[T] {
     return FinishOnce(it)
 }
+
+func (it Iterator[T]) ToSlice(capHint ...int) []T {
+    return ToSlice(it, capHint...)
+}
+
+func (it Iterator[T]) ForEach(f func(T) bool) {
+    ForEach(it, f)
+}
+
+func (it Iterator[T]) WriteTo(w io.Writer, format func(T) []byte) (int64, error) {
+    return WriteTo(it, w, format)
+}
+
+func (it Iterator[T]) OnFinish(f func()) Iterator[T] {
+    return OnFinish(it, f)
+}
+
+func (it Iterator[T]) Tap(f func(T)) Iterator[T] {
+    return Tap(it, f)
+}
@@ -24,12 +24,29 @@ func (it Iterator2[T1, T2]) PickV2() Iterator[T2] {
     return PickV2(it)
 }
 
-func (it Iterator2[T1, T2]) OrderBy(cmp func(*Combined[T1, T2], *Combined[T1, T2]) int) Iterator2[T1, T2] {
-    return Order2By(it, cmp)
+func (it Iterator2[T1, T2]) Swap() Iterator2[T2, T1] {
+    return Swap(it)
 }
 
-func (it Iterator2[T1, T2]) StableOrderBy(cmp func(*Combined[T1, T2], *Combined[T1, T2]) int) Iterator2[T1, T2] {
-    return StableOrder2By(it, cmp)
+// Flip is an alias of Swap method.
+func (it Iterator2[T1, T2]) Flip() Iterator2[T2, T1] {
+    return Swap(it)
+}
+
+func (it Iterator2[T1, T2]) TransformV1(transformer func(T1) T1) Iterator2[T1, T2] {
+    return TransformV1(it, transformer)
+}
+
+func (it Iterator2[T1, T2]) TransformV2(transformer func(T2) T2) Iterator2[T1, T2] {
+    return TransformV2(it, transformer)
+}
+
+func (it Iterator2[T1, T2]) OrderBy(cmp func(*Combined[T1, T2], *Combined[T1, T2]) int, opts ...BufferOption[*Combined[T1, T2]]) Iterator2[T1, T2] {
+    return Order2By(it, cmp, opts...)
+}
+
+func (it Iterator2[T1, T2]) StableOrderBy(cmp func(*Combined[T1, T2], *Combined[T1, T2]) int, opts ...BufferOption[*Combined[T1, T2]]) Iterator2[T1, T2] {
+    return StableOrder2By(it, cmp, opts...)
 }
 
 func (it Iterator2[T1, T2]) Filter(cmp func(T1, T2) bool) Iterator2[T1, T2] {
@@ -40,8 +57,8 @@ func (it Iterator2[T1, T2]) Take(n int) Iterator2[T1, T2] {
     return Take2(it, n)
 }
 
-func (it Iterator2[T1, T2]) TakeLast(n int) Iterator2[T1, T2] {
-    return TakeLast2(it, n)
+func (it Iterator2[T1, T2]) TakeLast(n int, opts ...BufferOption[*Combined[T1, T2]]) Iterator2[T1, T2] {
+    return TakeLast2(it, n, opts...)
 }
 
 func (it Iterator2[T1, T2]) Skip(n int) Iterator2[T1, T2] {
@@ -56,8 +73,8 @@ func (it Iterator2[T1, T2]) Concat(its ...Iterator2[T1, T2]) Iterator2[T1, T2] {
     return Concat2(it, its...)
 }
 
-func (it Iterator2[T1, T2]) Reverse() Iterator2[T1, T2] {
-    return Reverse2(it)
+func (it Iterator2[T1, T2]) Reverse(opts ...BufferOption[*Combined[T1, T2]]) Iterator2[T1, T2] {
+    return Reverse2(it, opts...)
 }
 
 func (it Iterator2[T1, T2]) Count() int {
@@ -68,8 +85,8 @@ func (it Iterator2[T1, T2]) Through(f func(T1, T2) (T1, T2)) Iterator2[T1, T2] {
     return Transform2(it, f)
 }
 
-func (it Iterator2[T1, T2]) Cache() Iterator2[T1, T2] {
-    return Cache2(it)
+func (it Iterator2[T1, T2]) Cache(opts ...BufferOption[*Combined[T1, T2]]) Iterator2[T1, T2] {
+    return Cache2(it, opts...)
 }
 
 func (it Iterator2[T1, T2]) Once() Iterator2[T1, T2] {
@@ -79,3 +96,29 @@ func (it Iterator2[T1, T2]) Once() Iterator2[T1, T2] {
 func (it Iterator2[T1, T2]) FinishOnce() Iterator2[T1, T2] {
     return FinishOnce2(it)
 }
+
+func (it Iterator2[T1, T2]) ToSlices(capHint ...int) ([]T1, []T2) {
+    return ToSlices2(it, capHint...)
+}
+
+// Keys collects the first elements of the pairs into a slice.
+func (it Iterator2[T1, T2]) Keys(capHint ...int) []T1 {
+    return ToSlice(PickV1(it), capHint...)
+}
+
+// Values collects the second elements of the pairs into a slice.
+func (it Iterator2[T1, T2]) Values(capHint ...int) []T2 {
+    return ToSlice(PickV2(it), capHint...)
+}
+
+func (it Iterator2[T1, T2]) ForEach(f func(T1, T2) bool) {
+    ForEach2(it, f)
+}
+
+func (it Iterator2[T1, T2]) OnFinish(f func()) Iterator2[T1, T2] {
+    return OnFinish2(it, f)
+}
+
+func (it Iterator2[T1, T2]) Tap(f func(T1, T2)) Iterator2[T1, T2] {
+    return Tap2(it, f)
+}
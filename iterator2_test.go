@@ -70,3 +70,134 @@ func TestIterator2_Cache(t *testing.T) {
         break
     }
 }
+
+func TestCacheKeyed(t *testing.T) {
+    count := 0
+    source := Transform2(Slice([]string{"a", "b", "a", "c"}), func(idx int, v string) (string, int) {
+        count++
+        return v, idx
+    })
+    cache := CacheKeyed(source)
+
+    for _, _ = range cache.Iterator() {
+        break
+    }
+    actual := []string{}
+    for k, v := range cache.Iterator() {
+        actual = append(actual, fmt.Sprintf("%s%d", k, v))
+    }
+    expect := []string{"a0", "b1", "a2", "c3"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+    if count != 5 {
+        t.Fatal(fmt.Sprintf("expect: %d, actual: %d", 5, count))
+    }
+
+    v, ok := cache.Lookup("a")
+    if !ok || v != 2 {
+        t.Fatal(fmt.Sprintf("expect: (2, true), actual: (%d, %v)", v, ok))
+    }
+    if _, ok := cache.Lookup("d"); ok {
+        t.Fatal("expect key d to be missing")
+    }
+    if cache.Len() != 3 {
+        t.Fatal(fmt.Sprintf("expect: 3, actual: %d", cache.Len()))
+    }
+    actual = []string{}
+    for k, v := range cache.Iterator() {
+        actual = append(actual, fmt.Sprintf("%s%d", k, v))
+    }
+    if !slices.Equal(expect, actual) || count != 5 {
+        t.Fatal(fmt.Sprintf("expect cached %v, actual: %v after %d calls", expect, actual, count))
+    }
+
+    lazy := CacheKeyed(Swap(Slice([]string{"x", "y"})))
+    if lazy.Len() != 2 {
+        t.Fatal(fmt.Sprintf("expect: 2, actual: %d", lazy.Len()))
+    }
+}
+
+func TestLookupLRU(t *testing.T) {
+    count := 0
+    source := Transform2(Slice([]string{"a", "b", "c", "d"}), func(idx int, v string) (string, int) {
+        count++
+        return v, idx
+    })
+    cache := LookupLRU(source, 2)
+
+    // a miss iterates the input iterator, and only the 2 most recently used pairs are kept
+    if v, ok := cache.Lookup("a"); !ok || v != 0 || count != 4 {
+        t.Fatal(fmt.Sprintf("expect (0, true) after 4 calls, actual: (%d, %v) after %d calls", v, ok, count))
+    }
+    if cache.Len() != 2 {
+        t.Fatal(fmt.Sprintf("expect: 2, actual: %d", cache.Len()))
+    }
+    // a and d are cached
+    if v, ok := cache.Lookup("d"); !ok || v != 3 || count != 4 {
+        t.Fatal(fmt.Sprintf("expect (3, true) after 4 calls, actual: (%d, %v) after %d calls", v, ok, count))
+    }
+    if v, ok := cache.Lookup("a"); !ok || v != 0 || count != 4 {
+        t.Fatal(fmt.Sprintf("expect (0, true) after 4 calls, actual: (%d, %v) after %d calls", v, ok, count))
+    }
+    if _, ok := cache.Lookup("x"); ok || count != 8 {
+        t.Fatal(fmt.Sprintf("expect key x to be missing after 8 calls, actual: %d calls", count))
+    }
+
+    // iterating refreshes the cache with the pairs that pass
+    for k, _ := range cache.Iterator() {
+        if k == "b" {
+            break
+        }
+    }
+    count = 0
+    if v, ok := cache.Lookup("b"); !ok || v != 1 || count != 0 {
+        t.Fatal(fmt.Sprintf("expect (1, true) without calls, actual: (%d, %v) after %d calls", v, ok, count))
+    }
+    if _, ok := cache.Lookup("c"); !ok || count != 4 {
+        t.Fatal(fmt.Sprintf("expect key c to be found after 4 calls, actual: %d calls", count))
+    }
+
+    // duplicated keys keep the last value
+    dup := LookupLRU(Swap(Slice([]string{"a", "b", "a"})), 10)
+    if v, ok := dup.Lookup("a"); !ok || v != 2 {
+        t.Fatal(fmt.Sprintf("expect: (2, true), actual: (%d, %v)", v, ok))
+    }
+
+    none := LookupLRU(Swap(Slice([]string{"a"})), 0)
+    if _, ok := none.Lookup("a"); !ok || none.Len() != 0 {
+        t.Fatal(fmt.Sprintf("expect key a to be found without caching, actual: %v, %d", ok, none.Len()))
+    }
+}
+
+func TestIterator2_Swap(t *testing.T) {
+    actual := []string{}
+    for v, idx := range Slice([]string{"a", "b"}).Swap() {
+        actual = append(actual, fmt.Sprintf("%s%d", v, idx))
+    }
+    expect := []string{"a0", "b1"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []string{}
+    for idx, v := range Slice([]string{"a", "b"}).Flip().Flip() {
+        actual = append(actual, fmt.Sprintf("%d%s", idx, v))
+    }
+    expect = []string{"0a", "1b"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestIterator2_KeysValues(t *testing.T) {
+    it := Slice([]string{"a", "b", "c"})
+    keys := it.Keys()
+    if !slices.Equal([]int{0, 1, 2}, keys) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{0, 1, 2}, keys))
+    }
+    values := it.Values(3)
+    if !slices.Equal([]string{"a", "b", "c"}, values) || cap(values) != 3 {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []string{"a", "b", "c"}, values))
+    }
+}
@@ -62,3 +62,63 @@ func TestIterator_Cache(t *testing.T) {
         break
     }
 }
+
+func TestIterator_CacheN(t *testing.T) {
+    count := 0
+    iterator := SliceElems([]int{1, 2, 3, 4, 5}).
+        Through(func(v int) int {
+            count++
+            return v
+        }).CacheN(2)
+
+    actual := iterator.Take(2).ToSlice()
+    if !slices.Equal([]int{1, 2}, actual) || count != 2 {
+        t.Fatal(fmt.Sprintf("expect: [1 2] with 2 computations, actual: %v with %d computations", actual, count))
+    }
+    actual = iterator.Take(2).ToSlice()
+    if !slices.Equal([]int{1, 2}, actual) || count != 2 {
+        t.Fatal(fmt.Sprintf("expect: [1 2] with 2 computations, actual: %v with %d computations", actual, count))
+    }
+    actual = iterator.ToSlice()
+    if !slices.Equal([]int{1, 2, 3, 4, 5}, actual) || count != 7 {
+        t.Fatal(fmt.Sprintf("expect: [1 2 3 4 5] with 7 computations, actual: %v with %d computations", actual, count))
+    }
+
+    count = 0
+    short := SliceElems([]int{1, 2}).
+        Through(func(v int) int {
+            count++
+            return v
+        }).CacheN(3)
+    for i := 0; i < 2; i++ {
+        actual = short.ToSlice()
+        if !slices.Equal([]int{1, 2}, actual) || count != 2 {
+            t.Fatal(fmt.Sprintf("expect: [1 2] with 2 computations, actual: %v with %d computations", actual, count))
+        }
+    }
+}
+
+func TestIterator_Zip(t *testing.T) {
+    actual := []string{}
+    for a, b := range Items("a", "b", "c").Zip(Items("x", "y")).Filter(func(a, b string) bool {
+        return a != "b"
+    }) {
+        actual = append(actual, a+b)
+    }
+    expect := []string{"ax"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    // different element types are zipped by the Zip function
+    actual = []string{}
+    for s, n := range Zip(Items("a", "b", "c"), Counter(1)).Filter(func(s string, n int) bool {
+        return n != 2
+    }) {
+        actual = append(actual, fmt.Sprintf("%s%d", s, n))
+    }
+    expect = []string{"a1", "c3"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
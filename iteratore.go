@@ -0,0 +1,229 @@
+package goiter
+
+import (
+    "errors"
+    "io"
+    "iter"
+    "runtime/debug"
+)
+
+// IteratorE is an iterator of values that may fail, each value is yielded with a nil error, or a failure is yielded as a non-nil error.
+// It has the same underlying type as Iterator2[T, error], so it can be used with all the functions accepting iter.Seq2.
+//
+// The sources reading from an io.Reader, such as FromNDJSON, FromCSV and CDCChunks, report their failures in this way.
+// The functions whose element type can't carry an error, such as TryTransformUntil, OpenLines and WalkDir,
+// store the error that stops the iteration into an errp out-parameter instead, errp can be nil to ignore the error.
+type IteratorE[T any] iter.Seq2[T, error]
+
+func (it IteratorE[T]) Seq() iter.Seq2[T, error] {
+    return iter.Seq2[T, error](it)
+}
+
+func (it IteratorE[T]) Iterator2() Iterator2[T, error] {
+    return Iterator2[T, error](it)
+}
+
+func (it IteratorE[T]) FilterOK() Iterator[T] {
+    return FilterOK(it)
+}
+
+func (it IteratorE[T]) StopOnError() IteratorE[T] {
+    return StopOnError(it)
+}
+
+func (it IteratorE[T]) Collect() ([]T, error) {
+    return CollectE(it)
+}
+
+// FilterOK returns an iterator that yields the values paired with a nil error, and drops the failures.
+func FilterOK[TIter Seq2X[T, error], T any](iterator TIter) Iterator[T] {
+    return func(yield func(T) bool) {
+        for v, err := range iterator {
+            if err != nil {
+                continue
+            }
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+// MapE returns an iterator that applies the transformer function to each successful value of the input iterator,
+// the failures of the input iterator are passed through, and the errors returned by the transformer are yielded as failures.
+// For example:
+//
+//  ages := goiter.MapE(goiter.FromCSV(r), func(record []string) (int, error) {
+//      return strconv.Atoi(record[1])
+//  })
+func MapE[TIter Seq2X[T, error], T any, TOut any](iterator TIter, transformer func(T) (TOut, error)) IteratorE[TOut] {
+    return func(yield func(TOut, error) bool) {
+        for v, err := range iterator {
+            var out TOut
+            if err == nil {
+                out, err = transformer(v)
+            }
+            if err != nil {
+                var zero TOut
+                out = zero
+            }
+            if !yield(out, err) {
+                return
+            }
+        }
+    }
+}
+
+// TryTransform is like Transform, but the transformer function can fail,
+// each transformed value is yielded with a nil error, or the zero value is yielded with the error returned by the transformer.
+// For example:
+//
+//  for v, err := range goiter.TryTransform(goiter.Items("1", "x"), strconv.Atoi) {
+//      ...
+//  }
+func TryTransform[TIter SeqX[T], T any, TOut any](iterator TIter, transformer func(T) (TOut, error)) IteratorE[TOut] {
+    return func(yield func(TOut, error) bool) {
+        for v := range iterator {
+            out, err := transformer(v)
+            if err != nil {
+                var zero TOut
+                out = zero
+            }
+            if !yield(out, err) {
+                return
+            }
+        }
+    }
+}
+
+// TryTransformUntil is like TryTransform, but the iteration stops at the first error, which is stored into *errp.
+// *errp is set to nil when the iteration starts, so it holds the error of the latest iteration.
+// If errp is nil, the iteration just stops at the first error.
+// For example:
+//
+//  var err error
+//  for v := range goiter.TryTransformUntil(lines, strconv.Atoi, &err) {
+//      sum += v
+//  }
+//  if err != nil {
+//      return err
+//  }
+func TryTransformUntil[TIter SeqX[T], T any, TOut any](iterator TIter, transformer func(T) (TOut, error), errp *error) Iterator[TOut] {
+    return func(yield func(TOut) bool) {
+        if errp != nil {
+            *errp = nil
+        }
+        for v := range iterator {
+            out, err := transformer(v)
+            if err != nil {
+                if errp != nil {
+                    *errp = err
+                }
+                return
+            }
+            if !yield(out) {
+                return
+            }
+        }
+    }
+}
+
+// Recover returns an iterator that passes through the values of the input iterator with a nil error,
+// and if the input iterator panics, including the functions it calls such as a transformer, the panic is recovered
+// and yielded as a *PanicError as the last element, instead of crashing the consuming goroutine.
+// Panics raised by the consumer's loop body are not recovered.
+// For example:
+//
+//  for v, err := range goiter.Recover(goiter.Transform(inputs, userSuppliedFunc)) {
+//      if err != nil {
+//          log.Println(err)
+//          break
+//      }
+//      ...
+//  }
+func Recover[TIter SeqX[T], T any](iterator TIter) IteratorE[T] {
+    return func(yield func(T, error) bool) {
+        inYield := false
+        defer func() {
+            if inYield {
+                // the panic comes from the consumer, let it propagate
+                return
+            }
+            if r := recover(); r != nil {
+                var zero T
+                yield(zero, &PanicError{Value: r, Stack: debug.Stack()})
+            }
+        }()
+        for v := range iterator {
+            inYield = true
+            if !yield(v, nil) {
+                return
+            }
+            inYield = false
+        }
+    }
+}
+
+// StopOnError returns an iterator that passes through the values of the input iterator until the first failure,
+// the failure is yielded as the last element.
+func StopOnError[TIter Seq2X[T, error], T any](iterator TIter) IteratorE[T] {
+    return func(yield func(T, error) bool) {
+        for v, err := range iterator {
+            if !yield(v, err) || err != nil {
+                return
+            }
+        }
+    }
+}
+
+// CollectE collects the successful values of the input iterator until the first failure,
+// and returns them along with the error of the first failure, or nil if there is none.
+func CollectE[TIter Seq2X[T, error], T any](iterator TIter) ([]T, error) {
+    values := make([]T, 0)
+    for v, err := range iterator {
+        if err != nil {
+            return values, err
+        }
+        values = append(values, v)
+    }
+    return values, nil
+}
+
+// FromFuncE returns an iterator that calls next repeatedly and yields what it returns.
+// The iteration ends when next returns io.EOF, or after yielding any other error.
+// For example:
+//
+//  dec := json.NewDecoder(r)
+//  iterator := goiter.FromFuncE(func() (Event, error) {
+//      var e Event
+//      err := dec.Decode(&e)
+//      return e, err
+//  })
+func FromFuncE[T any](next func() (T, error)) IteratorE[T] {
+    return func(yield func(T, error) bool) {
+        for {
+            v, err := next()
+            if errors.Is(err, io.EOF) {
+                return
+            }
+            if !yield(v, err) || err != nil {
+                return
+            }
+        }
+    }
+}
+
+// ToFuncE converts the input iterator to a pull-style next function, which returns io.EOF after the input iterator is exhausted.
+// The stop function must be called when the caller is done with next, just like iter.Pull2.
+func ToFuncE[TIter Seq2X[T, error], T any](iterator TIter) (next func() (T, error), stop func()) {
+    pull, stop := iter.Pull2(iter.Seq2[T, error](iterator))
+    next = func() (T, error) {
+        v, err, ok := pull()
+        if !ok {
+            var zero T
+            return zero, io.EOF
+        }
+        return v, err
+    }
+    return next, stop
+}
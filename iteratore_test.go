@@ -0,0 +1,189 @@
+package goiter
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "slices"
+    "strconv"
+    "testing"
+)
+
+func testIteratorE() IteratorE[int] {
+    return MapE(Parse(Items("1", "x", "3"), strconv.Atoi), func(v int) (int, error) {
+        return v * 10, nil
+    })
+}
+
+func TestFilterOK(t *testing.T) {
+    actual := testIteratorE().FilterOK().ToSlice()
+    expect := []int{10, 30}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestMapE(t *testing.T) {
+    errOdd := errors.New("odd")
+    actual := []string{}
+    for v, err := range MapE(testIteratorE(), func(v int) (string, error) {
+        if v == 30 {
+            return "skip", errOdd
+        }
+        return strconv.Itoa(v), nil
+    }) {
+        if err != nil {
+            actual = append(actual, fmt.Sprintf("err(%q)", v))
+            continue
+        }
+        actual = append(actual, v)
+    }
+    expect := []string{"10", `err("")`, `err("")`}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestStopOnError(t *testing.T) {
+    count := 0
+    var lastErr error
+    for _, err := range testIteratorE().StopOnError() {
+        count++
+        lastErr = err
+    }
+    var parseErr *ParseError
+    if count != 2 || !errors.As(lastErr, &parseErr) {
+        t.Fatal(fmt.Sprintf("expect 2 elements ending with a ParseError, actual: %d, %v", count, lastErr))
+    }
+}
+
+func TestCollectE(t *testing.T) {
+    values, err := testIteratorE().Collect()
+    if !slices.Equal([]int{10}, values) || err == nil {
+        t.Fatal(fmt.Sprintf("expect [10] and an error, actual: %v, %v", values, err))
+    }
+
+    values, err = CollectE(Parse(Items("1", "2"), strconv.Atoi))
+    if !slices.Equal([]int{1, 2}, values) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [1 2] and no error, actual: %v, %v", values, err))
+    }
+}
+
+func TestFromFuncE(t *testing.T) {
+    items := []int{1, 2, 3}
+    next := func() (int, error) {
+        if len(items) == 0 {
+            return 0, io.EOF
+        }
+        v := items[0]
+        items = items[1:]
+        return v, nil
+    }
+    values, err := CollectE(FromFuncE(next))
+    if !slices.Equal([]int{1, 2, 3}, values) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [1 2 3] and no error, actual: %v, %v", values, err))
+    }
+
+    errBoom := errors.New("boom")
+    count := 0
+    for _, err := range FromFuncE(func() (int, error) { return 0, errBoom }) {
+        count++
+        if err != errBoom {
+            t.Fatal(fmt.Sprintf("expect errBoom, actual: %v", err))
+        }
+    }
+    if count != 1 {
+        t.Fatal(fmt.Sprintf("expect the iteration to stop after the error, actual: %d elements", count))
+    }
+}
+
+func TestToFuncE(t *testing.T) {
+    next, stop := ToFuncE(testIteratorE())
+    defer stop()
+    actual := []string{}
+    for {
+        v, err := next()
+        if err == io.EOF {
+            break
+        }
+        actual = append(actual, fmt.Sprintf("%d:%v", v, err != nil))
+    }
+    expect := []string{"10:false", "0:true", "30:false"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestTryTransform(t *testing.T) {
+    actual := []string{}
+    for v, err := range TryTransform(Items("1", "x", "3"), strconv.Atoi) {
+        actual = append(actual, fmt.Sprintf("%d:%v", v, err != nil))
+    }
+    expect := []string{"1:false", "0:true", "3:false"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _, _ = range TryTransform(Items("1", "2"), strconv.Atoi) {
+        break
+    }
+}
+
+func TestTryTransformUntil(t *testing.T) {
+    var err error
+    actual := TryTransformUntil(Items("1", "x", "3"), strconv.Atoi, &err).ToSlice()
+    if !slices.Equal([]int{1}, actual) || err == nil {
+        t.Fatal(fmt.Sprintf("expect [1] and an error, actual: %v, %v", actual, err))
+    }
+
+    actual = TryTransformUntil(Items("1", "2"), strconv.Atoi, &err).ToSlice()
+    if !slices.Equal([]int{1, 2}, actual) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [1 2] and no error, actual: %v, %v", actual, err))
+    }
+
+    actual = TryTransformUntil(Items("1", "x", "3"), strconv.Atoi, nil).ToSlice()
+    if !slices.Equal([]int{1}, actual) {
+        t.Fatal(fmt.Sprintf("expect: [1], actual: %v", actual))
+    }
+}
+
+func TestRecover(t *testing.T) {
+    errBoom := errors.New("boom")
+    source := Transform(Range(1, 5), func(v int) int {
+        if v == 3 {
+            panic(errBoom)
+        }
+        return v
+    })
+    actual := []int{}
+    var lastErr error
+    for v, err := range Recover(source) {
+        if err != nil {
+            lastErr = err
+            continue
+        }
+        actual = append(actual, v)
+    }
+    var panicErr *PanicError
+    if !slices.Equal([]int{1, 2}, actual) || !errors.As(lastErr, &panicErr) || !errors.Is(lastErr, errBoom) || len(panicErr.Stack) == 0 {
+        t.Fatal(fmt.Sprintf("expect [1 2] and a PanicError wrapping errBoom, actual: %v, %v", actual, lastErr))
+    }
+
+    values, err := CollectE(Recover(Range(1, 3)))
+    if !slices.Equal([]int{1, 2, 3}, values) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [1 2 3] and no error, actual: %v, %v", values, err))
+    }
+
+    for _, _ = range Recover(source) {
+        break
+    }
+
+    defer func() {
+        if r := recover(); r != "consumer" {
+            t.Fatal(fmt.Sprintf("expect the consumer's panic to propagate, actual: %v", r))
+        }
+    }()
+    for _, _ = range Recover(Range(1, 3)) {
+        panic("consumer")
+    }
+}
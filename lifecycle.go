@@ -0,0 +1,181 @@
+package goiter
+
+import (
+    "bufio"
+    "io"
+    "os"
+    "sync"
+)
+
+// OnFinish returns an iterator that passes through the values of the input iterator,
+// and calls f when the iteration ends, whether the input iterator is exhausted, the consumer breaks out of the loop early, or a panic occurs.
+// f is called exactly once, after the first iteration ends, so it is a reliable place to release the resources that back the input iterator,
+// such as closing a file. f is not called if the iterator is never iterated over.
+// For example:
+//
+//  file, _ := os.Open("data.csv")
+//  records := goiter.OnFinish(goiter.FilterOK(goiter.FromCSV(file)), func() {
+//      file.Close()
+//  })
+func OnFinish[TIter SeqX[T], T any](iterator TIter, f func()) Iterator[T] {
+    once := &sync.Once{}
+    return func(yield func(T) bool) {
+        defer once.Do(f)
+        for v := range iterator {
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+// OnFinish2 is the iter.Seq2 version of OnFinish function.
+func OnFinish2[TIter Seq2X[T1, T2], T1, T2 any](iterator TIter, f func()) Iterator2[T1, T2] {
+    once := &sync.Once{}
+    return func(yield func(T1, T2) bool) {
+        defer once.Do(f)
+        for v1, v2 := range iterator {
+            if !yield(v1, v2) {
+                return
+            }
+        }
+    }
+}
+
+// ClosableIterator is an iterator backed by a resource that must be released, such as a file or a network connection.
+// The resource is closed automatically when the first iteration ends, and Close can also be called explicitly,
+// for example, when the iterator may never be iterated over. The resource is closed at most once,
+// so a ClosableIterator can only be iterated once.
+//
+// It is returned by the sources that open the resource themselves, such as OpenLines. The sources that read from
+// an io.Reader or an fs.FS, such as FromNDJSON, FromCSV, FromSSE and WalkDir, return plain iterators,
+// because they don't own the reader and must not close it, the caller can tie the reader to the pipeline with Closable.
+type ClosableIterator[T any] struct {
+    iterator  Iterator[T]
+    closer    func() error
+    closeOnce *sync.Once
+    closeErr  *error
+}
+
+// Closable returns a ClosableIterator that yields the values of the input iterator and closes closer when it is done.
+// For example:
+//
+//  file, err := os.Open("events.ndjson")
+//  if err != nil {
+//      return err
+//  }
+//  events := goiter.Closable(goiter.FromNDJSON[Event](ctx, file), file)
+//  defer events.Close()
+func Closable[TIter SeqX[T], T any](iterator TIter, closer io.Closer) *ClosableIterator[T] {
+    c := &ClosableIterator[T]{
+        closer:    closer.Close,
+        closeOnce: &sync.Once{},
+        closeErr:  new(error),
+    }
+    c.iterator = OnFinish(iterator, func() {
+        c.Close()
+    })
+    return c
+}
+
+// Iterator returns the iterator to range over.
+func (c *ClosableIterator[T]) Iterator() Iterator[T] {
+    return c.iterator
+}
+
+// Close releases the resource, and returns the error of closing it. It is safe to call Close multiple times.
+func (c *ClosableIterator[T]) Close() error {
+    c.closeOnce.Do(func() {
+        *c.closeErr = c.closer()
+    })
+    return *c.closeErr
+}
+
+// Then applies the stage to the iterator, and returns a ClosableIterator that shares the same resource,
+// so the resource is propagated through the chain of operators.
+// For example:
+//
+//  var readErr error
+//  lines, err := goiter.OpenLines("app.log", &readErr)
+//  if err != nil {
+//      return err
+//  }
+//  errors := lines.Then(goiter.FilterWith(func(line string) bool {
+//      return strings.Contains(line, "ERROR")
+//  }))
+//  defer errors.Close()
+func (c *ClosableIterator[T]) Then(stage Stage[T]) *ClosableIterator[T] {
+    return ThenTo(c, stage)
+}
+
+// ThenTo is like Then, but f can change the element type, such as Transform or Window,
+// so the resource is propagated through the type-changing operators as well.
+// For example:
+//
+//  lines, err := goiter.OpenLines("app.log", nil)
+//  if err != nil {
+//      return err
+//  }
+//  lengths := goiter.ThenTo(lines, func(it goiter.Iterator[string]) goiter.Iterator[int] {
+//      return goiter.Transform(it, func(line string) int {
+//          return len(line)
+//      })
+//  })
+//  defer lengths.Close()
+func ThenTo[T, U any](c *ClosableIterator[T], f func(Iterator[T]) Iterator[U]) *ClosableIterator[U] {
+    next := &ClosableIterator[U]{
+        closer:    c.closer,
+        closeOnce: c.closeOnce,
+        closeErr:  c.closeErr,
+    }
+    next.iterator = OnFinish(f(c.iterator), func() {
+        next.Close()
+    })
+    return next
+}
+
+// OpenLines opens the named file, and returns a ClosableIterator that yields its lines without the line endings.
+// The iteration stops when the end of the file is reached, or at the first read error, which is stored into *errp,
+// so a failed read can be told apart from a short file. *errp is set to nil when the iteration starts,
+// errp can be nil if the read error is not needed.
+// Like every ClosableIterator, it can only be iterated once, the file is closed when the first iteration ends,
+// so a later iteration yields nothing and reports an error wrapping os.ErrClosed.
+// For example:
+//
+//  var readErr error
+//  lines, err := goiter.OpenLines("app.log", &readErr)
+//  if err != nil {
+//      return err
+//  }
+//  defer lines.Close()
+//  for line := range lines.Iterator() {
+//      ...
+//  }
+//  if readErr != nil {
+//      return readErr
+//  }
+func OpenLines(name string, errp *error) (*ClosableIterator[string], error) {
+    file, err := os.Open(name)
+    if err != nil {
+        return nil, err
+    }
+    lines := func(yield func(string) bool) {
+        if errp != nil {
+            *errp = nil
+        }
+        br := bufio.NewReader(file)
+        for {
+            line, err := readLine(br)
+            if err != nil {
+                if err != io.EOF && errp != nil {
+                    *errp = err
+                }
+                return
+            }
+            if !yield(line) {
+                return
+            }
+        }
+    }
+    return Closable(lines, file), nil
+}
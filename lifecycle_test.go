@@ -0,0 +1,197 @@
+package goiter
+
+import (
+    "errors"
+    "fmt"
+    "os"
+    "path/filepath"
+    "slices"
+    "testing"
+)
+
+func TestOnFinish(t *testing.T) {
+    calls := 0
+    iterator := Range(1, 3).OnFinish(func() { calls++ })
+    actual := iterator.ToSlice()
+    if !slices.Equal([]int{1, 2, 3}, actual) || calls != 1 {
+        t.Fatal(fmt.Sprintf("expect [1 2 3] and 1 call, actual: %v, %d calls", actual, calls))
+    }
+    iterator.ToSlice()
+    if calls != 1 {
+        t.Fatal(fmt.Sprintf("expect f to be called exactly once, actual: %d calls", calls))
+    }
+
+    calls = 0
+    for _ = range OnFinish(Counter(0), func() { calls++ }) {
+        break
+    }
+    if calls != 1 {
+        t.Fatal(fmt.Sprintf("expect f to be called on break, actual: %d calls", calls))
+    }
+
+    calls = 0
+    func() {
+        defer func() {
+            recover()
+        }()
+        for _ = range OnFinish(Counter(0), func() { calls++ }) {
+            panic("boom")
+        }
+    }()
+    if calls != 1 {
+        t.Fatal(fmt.Sprintf("expect f to be called on panic, actual: %d calls", calls))
+    }
+}
+
+func TestOnFinish2(t *testing.T) {
+    calls := 0
+    iterator := Slice([]int{1, 2, 3}).OnFinish(func() { calls++ })
+    for _, _ = range iterator {
+        break
+    }
+    if calls != 1 {
+        t.Fatal(fmt.Sprintf("expect 1 call, actual: %d calls", calls))
+    }
+    if Count2(iterator) != 3 || calls != 1 {
+        t.Fatal(fmt.Sprintf("expect f to be called exactly once, actual: %d calls", calls))
+    }
+}
+
+type testCloser struct {
+    calls int
+    err   error
+}
+
+func (c *testCloser) Close() error {
+    c.calls++
+    return c.err
+}
+
+func TestClosable(t *testing.T) {
+    closer := &testCloser{err: errors.New("close failed")}
+    c := Closable(Range(1, 5), closer)
+    actual := []int{}
+    for v := range c.Iterator() {
+        actual = append(actual, v)
+        if v == 2 {
+            break
+        }
+    }
+    if !slices.Equal([]int{1, 2}, actual) || closer.calls != 1 {
+        t.Fatal(fmt.Sprintf("expect [1 2] and the resource closed, actual: %v, %d calls", actual, closer.calls))
+    }
+    if err := c.Close(); err != closer.err || closer.calls != 1 {
+        t.Fatal(fmt.Sprintf("expect the first close error and a single close, actual: %v, %d calls", err, closer.calls))
+    }
+
+    closer = &testCloser{}
+    c = Closable(Range(1, 5), closer)
+    evens := c.Then(FilterWith(func(v int) bool { return v%2 == 0 })).Then(TakeN[int](1))
+    actual = evens.Iterator().ToSlice()
+    if !slices.Equal([]int{2}, actual) || closer.calls != 1 {
+        t.Fatal(fmt.Sprintf("expect [2] and the resource closed, actual: %v, %d calls", actual, closer.calls))
+    }
+    c.Close()
+    evens.Close()
+    if closer.calls != 1 {
+        t.Fatal(fmt.Sprintf("expect the resource to be closed once, actual: %d calls", closer.calls))
+    }
+
+    closer = &testCloser{}
+    Closable(Range(1, 5), closer).Close()
+    if closer.calls != 1 {
+        t.Fatal(fmt.Sprintf("expect the resource to be closed explicitly, actual: %d calls", closer.calls))
+    }
+}
+
+func TestOpenLines(t *testing.T) {
+    name := filepath.Join(t.TempDir(), "lines.txt")
+    if err := os.WriteFile(name, []byte("a\r\nb\n\nc"), 0o600); err != nil {
+        t.Fatal(err)
+    }
+    var readErr error
+    lines, err := OpenLines(name, &readErr)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer lines.Close()
+    actual := lines.Iterator().ToSlice()
+    expect := []string{"a", "b", "", "c"}
+    if !slices.Equal(expect, actual) || readErr != nil {
+        t.Fatal(fmt.Sprintf("expect: %q without error, actual: %q with error %v", expect, actual, readErr))
+    }
+    // the file is closed after the first iteration
+    if n := lines.Iterator().Count(); n != 0 || !errors.Is(readErr, os.ErrClosed) {
+        t.Fatal(fmt.Sprintf("expect no lines and os.ErrClosed, actual: %d lines and error %v", n, readErr))
+    }
+
+    if _, err := OpenLines(filepath.Join(t.TempDir(), "missing"), &readErr); err == nil {
+        t.Fatal("expect an error for a missing file")
+    }
+
+    // a directory can be opened, but reading it fails
+    dirLines, err := OpenLines(t.TempDir(), &readErr)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer dirLines.Close()
+    if n := dirLines.Iterator().Count(); n != 0 || readErr == nil {
+        t.Fatal(fmt.Sprintf("expect no lines and a read error, actual: %d lines and error %v", n, readErr))
+    }
+
+    // the read error can be ignored
+    dirLines, err = OpenLines(t.TempDir(), nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer dirLines.Close()
+    if n := dirLines.Iterator().Count(); n != 0 {
+        t.Fatal(fmt.Sprintf("expect no lines, actual: %d lines", n))
+    }
+}
+
+func TestThenTo(t *testing.T) {
+    name := filepath.Join(t.TempDir(), "lines.txt")
+    if err := os.WriteFile(name, []byte("a\nbb\nccc"), 0o600); err != nil {
+        t.Fatal(err)
+    }
+    toLengths := func(it Iterator[string]) Iterator[int] {
+        return Transform(it, func(line string) int {
+            return len(line)
+        })
+    }
+
+    var readErr error
+    lines, err := OpenLines(name, &readErr)
+    if err != nil {
+        t.Fatal(err)
+    }
+    lengths := ThenTo(lines, toLengths)
+    actual := []int{}
+    for n := range lengths.Iterator() {
+        actual = append(actual, n)
+        if n == 2 {
+            break
+        }
+    }
+    if !slices.Equal([]int{1, 2}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2}, actual))
+    }
+    // breaking out of the transformed chain closes the file
+    if n := lines.Iterator().Count(); n != 0 || !errors.Is(readErr, os.ErrClosed) {
+        t.Fatal(fmt.Sprintf("expect no lines and os.ErrClosed, actual: %d lines and error %v", n, readErr))
+    }
+
+    // closing the transformed chain closes the file without iterating
+    lines, err = OpenLines(name, &readErr)
+    if err != nil {
+        t.Fatal(err)
+    }
+    lengths = ThenTo(lines, toLengths)
+    if err := lengths.Close(); err != nil {
+        t.Fatal(fmt.Sprintf("expect no close error, actual: %v", err))
+    }
+    if n := lengths.Iterator().Count(); n != 0 || !errors.Is(readErr, os.ErrClosed) {
+        t.Fatal(fmt.Sprintf("expect no lines and os.ErrClosed, actual: %d lines and error %v", n, readErr))
+    }
+}
@@ -0,0 +1,133 @@
+// Package numbers provides iterators over small number-theory sequences built upon goiter.
+package numbers
+
+import (
+    "github.com/hsldymq/goiter"
+)
+
+// Digits returns an iterator that yields the digits of n in the given base, from the most significant digit to the least significant one.
+// The sign of n is ignored, and 0 yields a single 0.
+// For example:
+//
+//  numbers.Digits(1024, 10)   // will yield 1 0 2 4
+//  numbers.Digits(10, 2)      // will yield 1 0 1 0
+//
+// If base is less than 2, it yields nothing.
+func Digits(n int, base int) goiter.Iterator[int] {
+    if base < 2 {
+        return goiter.Empty[int]()
+    }
+
+    return func(yield func(int) bool) {
+        u := uint64(n)
+        if n < 0 {
+            u = -u
+        }
+        b := uint64(base)
+        div := uint64(1)
+        for u/div >= b {
+            div *= b
+        }
+        for ; div > 0; div /= b {
+            if !yield(int(u / div % b)) {
+                return
+            }
+        }
+    }
+}
+
+// Divisors returns an iterator that yields all positive divisors of n in ascending order.
+// So numbers.Divisors(12) will yield 1 2 3 4 6 12.
+// If n is less than or equal to 0, it yields nothing.
+func Divisors(n int) goiter.Iterator[int] {
+    if n <= 0 {
+        return goiter.Empty[int]()
+    }
+
+    return func(yield func(int) bool) {
+        var large []int
+        for i := 1; i <= n/i; i++ {
+            if n%i != 0 {
+                continue
+            }
+            if !yield(i) {
+                return
+            }
+            if i != n/i {
+                large = append(large, n/i)
+            }
+        }
+        for i := len(large) - 1; i >= 0; i-- {
+            if !yield(large[i]) {
+                return
+            }
+        }
+    }
+}
+
+// Collatz returns an iterator that yields the Collatz sequence starting from n until it reaches 1.
+// So numbers.Collatz(6) will yield 6 3 10 5 16 8 4 2 1.
+// If n is less than or equal to 0, it yields nothing.
+func Collatz(n int) goiter.Iterator[int] {
+    if n <= 0 {
+        return goiter.Empty[int]()
+    }
+
+    return func(yield func(int) bool) {
+        curr := n
+        for {
+            if !yield(curr) {
+                return
+            }
+            if curr == 1 {
+                return
+            }
+            if curr%2 == 0 {
+                curr /= 2
+            } else {
+                curr = 3*curr + 1
+            }
+        }
+    }
+}
+
+// Fibonacci returns an iterator that yields the Fibonacci numbers 0, 1, 1, 2, 3, 5, 8 and so on.
+// It stops after the largest Fibonacci number that fits in uint64, which is the 94th one.
+func Fibonacci() goiter.Iterator[uint64] {
+    return func(yield func(uint64) bool) {
+        a, b := uint64(0), uint64(1)
+        for {
+            if !yield(a) {
+                return
+            }
+            if b < a {
+                // b has overflowed
+                return
+            }
+            a, b = b, a+b
+        }
+    }
+}
+
+// Primes returns an infinite iterator that yields the prime numbers 2, 3, 5, 7, 11 and so on.
+// It uses an incremental sieve, so the primes are produced lazily without an upper bound decided in advance.
+func Primes() goiter.Iterator[int] {
+    return func(yield func(int) bool) {
+        // composites maps each upcoming composite number to the primes that divide it
+        composites := map[int][]int{}
+        for n := 2; ; n++ {
+            factors, ok := composites[n]
+            if !ok {
+                if !yield(n) {
+                    return
+                }
+                composites[n*n] = []int{n}
+                continue
+            }
+            for _, p := range factors {
+                composites[n+p] = append(composites[n+p], p)
+            }
+            delete(composites, n)
+        }
+    }
+}
@@ -0,0 +1,132 @@
+package numbers
+
+import (
+    "fmt"
+    "slices"
+    "testing"
+)
+
+func TestDigits(t *testing.T) {
+    testCases := []struct {
+        n      int
+        base   int
+        expect []int
+    }{
+        {1024, 10, []int{1, 0, 2, 4}},
+        {10, 2, []int{1, 0, 1, 0}},
+        {-255, 16, []int{15, 15}},
+        {0, 10, []int{0}},
+        {7, 10, []int{7}},
+        {10, 1, []int{}},
+    }
+    for _, tc := range testCases {
+        actual := []int{}
+        for each := range Digits(tc.n, tc.base) {
+            actual = append(actual, each)
+        }
+        if !slices.Equal(tc.expect, actual) {
+            t.Fatal(fmt.Sprintf("expect: %v, actual: %v", tc.expect, actual))
+        }
+    }
+
+    for _ = range Digits(1024, 10) {
+        break
+    }
+}
+
+func TestDivisors(t *testing.T) {
+    testCases := []struct {
+        n      int
+        expect []int
+    }{
+        {12, []int{1, 2, 3, 4, 6, 12}},
+        {16, []int{1, 2, 4, 8, 16}},
+        {13, []int{1, 13}},
+        {1, []int{1}},
+        {0, []int{}},
+    }
+    for _, tc := range testCases {
+        actual := []int{}
+        for each := range Divisors(tc.n) {
+            actual = append(actual, each)
+        }
+        if !slices.Equal(tc.expect, actual) {
+            t.Fatal(fmt.Sprintf("expect: %v, actual: %v", tc.expect, actual))
+        }
+    }
+
+    actual := []int{}
+    for each := range Divisors(12) {
+        actual = append(actual, each)
+        if each == 6 {
+            break
+        }
+    }
+    expect := []int{1, 2, 3, 4, 6}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestCollatz(t *testing.T) {
+    actual := []int{}
+    for each := range Collatz(6) {
+        actual = append(actual, each)
+    }
+    expect := []int{6, 3, 10, 5, 16, 8, 4, 2, 1}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    if Collatz(0).Count() != 0 {
+        t.Fatal("expect Collatz(0) to yield nothing")
+    }
+
+    for _ = range Collatz(27) {
+        break
+    }
+}
+
+func TestFibonacci(t *testing.T) {
+    actual := []uint64{}
+    for v := range Fibonacci() {
+        actual = append(actual, v)
+        if len(actual) == 10 {
+            break
+        }
+    }
+    expect := []uint64{0, 1, 1, 2, 3, 5, 8, 13, 21, 34}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    all := Fibonacci().ToSlice()
+    if len(all) != 94 || all[93] != 12200160415121876738 {
+        t.Fatal(fmt.Sprintf("expect 94 numbers ending with 12200160415121876738, actual: %d numbers ending with %d", len(all), all[len(all)-1]))
+    }
+}
+
+func TestPrimes(t *testing.T) {
+    actual := []int{}
+    for v := range Primes() {
+        if v > 50 {
+            break
+        }
+        actual = append(actual, v)
+    }
+    expect := []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    count := 0
+    for v := range Primes() {
+        count++
+        if count == 1000 {
+            if v != 7919 {
+                t.Fatal(fmt.Sprintf("expect the 1000th prime to be 7919, actual: %d", v))
+            }
+            break
+        }
+    }
+}
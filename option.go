@@ -0,0 +1,99 @@
+package goiter
+
+import "cmp"
+
+// Option represents an optional value, it is either Some value or None.
+// It is returned by the terminal functions that might have no result, such as FirstOpt, LastOpt, MinOpt and MaxOpt.
+type Option[T any] struct {
+    value T
+    ok    bool
+}
+
+// Some returns an Option that holds the value v.
+func Some[T any](v T) Option[T] {
+    return Option[T]{value: v, ok: true}
+}
+
+// None returns an Option that holds no value.
+func None[T any]() Option[T] {
+    return Option[T]{}
+}
+
+// IsSome reports whether the Option holds a value.
+func (o Option[T]) IsSome() bool {
+    return o.ok
+}
+
+// IsNone reports whether the Option holds no value.
+func (o Option[T]) IsNone() bool {
+    return !o.ok
+}
+
+// Unwrap returns the value held by the Option, it panics if the Option is None.
+func (o Option[T]) Unwrap() T {
+    if !o.ok {
+        panic("goiter: unwrap a None Option")
+    }
+    return o.value
+}
+
+// UnwrapOr returns the value held by the Option, or the fallback value if the Option is None.
+func (o Option[T]) UnwrapOr(fallback T) T {
+    if !o.ok {
+        return fallback
+    }
+    return o.value
+}
+
+// Get returns the value held by the Option and whether it is Some, so it can be used in the comma-ok form.
+func (o Option[T]) Get() (T, bool) {
+    return o.value, o.ok
+}
+
+// FromOption returns an iterator that yields the value held by the Option, or yields nothing if the Option is None.
+func FromOption[T any](o Option[T]) Iterator[T] {
+    return func(yield func(T) bool) {
+        if o.ok {
+            yield(o.value)
+        }
+    }
+}
+
+// FirstOpt returns the first value yielded by the input iterator, or None if it yields nothing.
+func FirstOpt[TIter SeqX[T], T any](iterator TIter) Option[T] {
+    for v := range iterator {
+        return Some(v)
+    }
+    return None[T]()
+}
+
+// LastOpt returns the last value yielded by the input iterator, or None if it yields nothing.
+func LastOpt[TIter SeqX[T], T any](iterator TIter) Option[T] {
+    result := None[T]()
+    for v := range iterator {
+        result = Some(v)
+    }
+    return result
+}
+
+// MinOpt returns the minimum value yielded by the input iterator, or None if it yields nothing.
+func MinOpt[TIter SeqX[T], T cmp.Ordered](iterator TIter) Option[T] {
+    result := None[T]()
+    for v := range iterator {
+        if !result.ok || v < result.value {
+            result = Some(v)
+        }
+    }
+    return result
+}
+
+// MaxOpt returns the maximum value yielded by the input iterator, or None if it yields nothing.
+func MaxOpt[TIter SeqX[T], T cmp.Ordered](iterator TIter) Option[T] {
+    result := None[T]()
+    for v := range iterator {
+        if !result.ok || v > result.value {
+            result = Some(v)
+        }
+    }
+    return result
+}
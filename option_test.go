@@ -0,0 +1,79 @@
+package goiter
+
+import (
+    "fmt"
+    "slices"
+    "testing"
+)
+
+func TestOption(t *testing.T) {
+    some := Some(3)
+    if !some.IsSome() || some.IsNone() || some.Unwrap() != 3 || some.UnwrapOr(5) != 3 {
+        t.Fatal(fmt.Sprintf("unexpected option: %v", some))
+    }
+    if v, ok := some.Get(); v != 3 || !ok {
+        t.Fatal(fmt.Sprintf("expect: 3 true, actual: %v %v", v, ok))
+    }
+
+    none := None[int]()
+    if none.IsSome() || !none.IsNone() || none.UnwrapOr(5) != 5 {
+        t.Fatal(fmt.Sprintf("unexpected option: %v", none))
+    }
+    if v, ok := none.Get(); v != 0 || ok {
+        t.Fatal(fmt.Sprintf("expect: 0 false, actual: %v %v", v, ok))
+    }
+
+    defer func() {
+        if r := recover(); r == nil {
+            t.Fatal("expect Unwrap on None to panic")
+        }
+    }()
+    none.Unwrap()
+}
+
+func TestFromOption(t *testing.T) {
+    actual := []int{}
+    for v := range FromOption(Some(1)).Concat(FromOption(None[int]()), FromOption(Some(2))) {
+        actual = append(actual, v)
+    }
+    expect := []int{1, 2}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestFirstOpt(t *testing.T) {
+    if v := FirstOpt(Counter(5)); v.Unwrap() != 5 {
+        t.Fatal(fmt.Sprintf("expect: 5, actual: %v", v))
+    }
+    if v := FirstOpt(Empty[int]()); v.IsSome() {
+        t.Fatal(fmt.Sprintf("expect None, actual: %v", v))
+    }
+}
+
+func TestLastOpt(t *testing.T) {
+    if v := LastOpt(Range(1, 5)); v.Unwrap() != 5 {
+        t.Fatal(fmt.Sprintf("expect: 5, actual: %v", v))
+    }
+    if v := LastOpt(Empty[int]()); v.IsSome() {
+        t.Fatal(fmt.Sprintf("expect None, actual: %v", v))
+    }
+}
+
+func TestMinOpt(t *testing.T) {
+    if v := MinOpt(Items(3, 1, 2)); v.Unwrap() != 1 {
+        t.Fatal(fmt.Sprintf("expect: 1, actual: %v", v))
+    }
+    if v := MinOpt(Empty[int]()); v.IsSome() {
+        t.Fatal(fmt.Sprintf("expect None, actual: %v", v))
+    }
+}
+
+func TestMaxOpt(t *testing.T) {
+    if v := MaxOpt(Items("b", "c", "a")); v.Unwrap() != "c" {
+        t.Fatal(fmt.Sprintf("expect: c, actual: %v", v))
+    }
+    if v := MaxOpt(Empty[string]()); v.IsSome() {
+        t.Fatal(fmt.Sprintf("expect None, actual: %v", v))
+    }
+}
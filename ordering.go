@@ -2,6 +2,7 @@ package goiter
 
 import (
     "cmp"
+    "iter"
     "slices"
 )
 
@@ -29,7 +30,7 @@ func Order[TIter SeqX[T], T cmp.Ordered](
         }
     }
 
-    return doOrderBy(iterator, cmpFunc, slices.SortFunc[[]T, T])
+    return doOrderBy(iterator, cmpFunc, false)
 }
 
 // Order2V1 sorts the 2-tuples of the input iterator by first element and returns a new iterator whose elements are arranged in ascending or descending order.
@@ -57,7 +58,7 @@ func Order2V1[TIter Seq2X[T1, T2], T1 cmp.Ordered, T2 any](
         }
     }
 
-    return doOrderBy2(iterator, cmpFunc, slices.SortFunc[[]*Combined[T1, T2], *Combined[T1, T2]])
+    return doOrderBy2(iterator, cmpFunc, false)
 }
 
 // Order2V2 is like Order2V1, but it sorts by the second element of the 2-tuples.
@@ -77,72 +78,171 @@ func Order2V2[TIter Seq2X[T1, T2], T1 any, T2 cmp.Ordered](
         }
     }
 
-    return doOrderBy2(iterator, cmpFunc, slices.SortFunc[[]*Combined[T1, T2], *Combined[T1, T2]])
+    return doOrderBy2(iterator, cmpFunc, false)
 }
 
 // OrderBy accepts a comparison function and returns a new iterator that yields elements sorted by the comparison function.
+// The elements are sorted lazily, so if only the first few elements are consumed, such as OrderBy(...).Take(10),
+// it is much cheaper than sorting the whole input. This applies to all Order* functions.
 // Note: if this function is used on iterators that has massive amount of data, it might consume a lot of memory.
+// The memory can be limited by WithMemoryBudget, and with WithSpill, the sorted runs are spilled to disk and merged like OrderExternal.
 func OrderBy[TIter SeqX[T], T any](
     iterator TIter,
     cmp func(T, T) int,
+    opts ...BufferOption[T],
 ) Iterator[T] {
-    return doOrderBy(iterator, cmp, slices.SortFunc[[]T, T])
+    return doOrderBy(iterator, cmp, false, opts...)
 }
 
 // Order2By is the iter.Seq2 version of OrderBy.
 // Note: if this function is used on iterators that has massive amount of data, it might consume a lot of memory.
+// The memory can be limited by the options of element type *Combined[T1, T2], see BufferOption.
 func Order2By[TIter Seq2X[T1, T2], T1, T2 any](
     iterator TIter,
     cmp func(*Combined[T1, T2], *Combined[T1, T2]) int,
+    opts ...BufferOption[*Combined[T1, T2]],
 ) Iterator2[T1, T2] {
-    return doOrderBy2(iterator, cmp, slices.SortFunc[[]*Combined[T1, T2], *Combined[T1, T2]])
+    return doOrderBy2(iterator, cmp, false, opts...)
 }
 
 // StableOrderBy is like OrderBy, but it uses a stable sort algorithm.
 // Note: if this function is used on iterators that has massive amount of data, it might consume a lot of memory.
+// The memory can be limited in the same way as OrderBy.
 func StableOrderBy[TIter SeqX[T], T any](
     iterator TIter,
     cmp func(T, T) int,
+    opts ...BufferOption[T],
 ) Iterator[T] {
-    return doOrderBy(iterator, cmp, slices.SortStableFunc[[]T, T])
+    return doOrderBy(iterator, cmp, true, opts...)
 }
 
 // StableOrder2By is like Order2By, but it uses a stable sort algorithm.
 // Note: if this function is used on iterators that has massive amount of data, it might consume a lot of memory.
+// The memory can be limited in the same way as Order2By.
 func StableOrder2By[TIter Seq2X[T1, T2], T1, T2 any](
     iterator TIter,
     cmp func(*Combined[T1, T2], *Combined[T1, T2]) int,
+    opts ...BufferOption[*Combined[T1, T2]],
 ) Iterator2[T1, T2] {
-    return doOrderBy2(iterator, cmp, slices.SortStableFunc[[]*Combined[T1, T2], *Combined[T1, T2]])
+    return doOrderBy2(iterator, cmp, true, opts...)
 }
 
-type tSortFunc[S ~[]T, T any] func(x S, cmp func(a, b T) int)
+// IsSorted reports whether the elements of the input iterator are in ascending order.
+// It stops consuming the input iterator as soon as it finds an element out of order.
+func IsSorted[TIter SeqX[T], T cmp.Ordered](iterator TIter) bool {
+    return IsSortedBy(iterator, cmp.Compare[T])
+}
+
+// IsSortedBy is like IsSorted, but it uses the comparison function to compare the elements.
+func IsSortedBy[TIter SeqX[T], T any](
+    iterator TIter,
+    cmp func(T, T) int,
+) bool {
+    var prev T
+    first := true
+    for v := range iterator {
+        if !first && cmp(prev, v) > 0 {
+            return false
+        }
+        first = false
+        prev = v
+    }
+    return true
+}
+
+// IsSorted2V1 reports whether the 2-tuples of the input iterator are in ascending order by the first element.
+func IsSorted2V1[TIter Seq2X[T1, T2], T1 cmp.Ordered, T2 any](iterator TIter) bool {
+    return IsSortedBy(PickV1(iterator), cmp.Compare[T1])
+}
+
+// IsSorted2V2 reports whether the 2-tuples of the input iterator are in ascending order by the second element.
+func IsSorted2V2[TIter Seq2X[T1, T2], T1 any, T2 cmp.Ordered](iterator TIter) bool {
+    return IsSortedBy(PickV2(iterator), cmp.Compare[T2])
+}
+
+// IsSorted2By is the iter.Seq2 version of IsSortedBy, the comparison function has the same form as the one of Order2By.
+func IsSorted2By[TIter Seq2X[T1, T2], T1, T2 any](
+    iterator TIter,
+    cmp func(*Combined[T1, T2], *Combined[T1, T2]) int,
+) bool {
+    var prev *Combined[T1, T2]
+    for v1, v2 := range iterator {
+        curr := &Combined[T1, T2]{V1: v1, V2: v2}
+        if prev != nil && cmp(prev, curr) > 0 {
+            return false
+        }
+        prev = curr
+    }
+    return true
+}
 
 func doOrderBy[TIter SeqX[T], T any](
     iterator TIter,
     cmp func(T, T) int,
-    sortFunc tSortFunc[[]T, T],
+    stable bool,
+    opts ...BufferOption[T],
 ) Iterator[T] {
+    if budget := newBufferBudget[T](opts); budget != nil {
+        return Iterator[T](orderWithin(iter.Seq[T](iterator), cmp, stable, budget))
+    }
     return func(yield func(T) bool) {
         s := make([]T, 0)
         for each := range iterator {
             s = append(s, each)
         }
 
-        sortFunc(s, cmp)
-        for _, each := range s {
-            if !yield(each) {
+        yieldOrdered(s, cmp, stable, yield)
+    }
+}
+
+// orderWithin is doOrderBy with a memory budget. Once a run is spilled, the rest of the buffer is spilled as well,
+// and the runs are merged like OrderExternal, the merge is stable, so it serves both OrderBy and StableOrderBy.
+func orderWithin[T any](iterator iter.Seq[T], cmp func(T, T) int, stable bool, budget *bufferBudget[T]) iter.Seq[T] {
+    return func(yield func(T) bool) {
+        budget.start()
+        runs, buffer, err := budget.collect(iterator, cmp)
+        defer func() {
+            removeFiles(runs)
+        }()
+        if err != nil {
+            budget.fail(err)
+            return
+        }
+        if len(runs) == 0 {
+            yieldOrdered(buffer, cmp, stable, yield)
+            return
+        }
+
+        if len(buffer) > 0 {
+            name, err := spillRun(buffer, cmp, budget.codec, budget.dir)
+            if name != "" {
+                runs = append(runs, name)
+            }
+            if err != nil {
+                budget.fail(err)
                 return
             }
         }
+        buffer = nil
+        if runs, err = reduceRuns(runs, cmp, budget.codec, budget.dir); err != nil {
+            budget.fail(err)
+            return
+        }
+        if err := mergeRuns(runs, cmp, budget.codec, yield); err != nil {
+            budget.fail(err)
+        }
     }
 }
 
 func doOrderBy2[TIter Seq2X[T1, T2], T1, T2 any](
     iterator TIter,
     cmp func(*Combined[T1, T2], *Combined[T1, T2]) int,
-    sortFunc tSortFunc[[]*Combined[T1, T2], *Combined[T1, T2]],
+    stable bool,
+    opts ...BufferOption[*Combined[T1, T2]],
 ) Iterator2[T1, T2] {
+    if budget := newBufferBudget[*Combined[T1, T2]](opts); budget != nil {
+        return uncombineSeq(orderWithin(combineSeq(iter.Seq2[T1, T2](iterator)), cmp, stable, budget))
+    }
     return func(yield func(T1, T2) bool) {
         tuples := make([]*Combined[T1, T2], 0)
         for v1, v2 := range iterator {
@@ -152,11 +252,152 @@ func doOrderBy2[TIter Seq2X[T1, T2], T1, T2 any](
             })
         }
 
-        sortFunc(tuples, cmp)
-        for _, each := range tuples {
-            if !yield(each.V1, each.V2) {
+        yieldOrdered(tuples, cmp, stable, func(each *Combined[T1, T2]) bool {
+            return yield(each.V1, each.V2)
+        })
+    }
+}
+
+func yieldOrdered[T any](s []T, cmp func(T, T) int, stable bool, yield func(T) bool) {
+    if !stable {
+        yieldSorted(s, cmp, yield)
+        return
+    }
+
+    entries := make([]stableEntry[T], len(s))
+    for i, each := range s {
+        entries[i] = stableEntry[T]{idx: i, v: each}
+    }
+    yieldSorted(entries, stableCmp(cmp), func(e stableEntry[T]) bool {
+        return yield(e.v)
+    })
+}
+
+// yieldSorted yields the elements of s in the order defined by cmp, it reorders s in place.
+// Instead of sorting the whole slice in advance, it heapifies the slice in O(n) time and picks the first few elements from the heap,
+// so when the consumer only needs a few elements, such as OrderBy followed by Take(n) or breaking out of the loop early,
+// it doesn't pay the cost of a full sort. If the consumer keeps iterating, the rest of the elements are sorted at once.
+func yieldSorted[T any](s []T, cmp func(T, T) int, yield func(T) bool) {
+    lazyCount := max(16, len(s)/64)
+    if lazyCount < len(s) {
+        for i := len(s)/2 - 1; i >= 0; i-- {
+            siftDown(s, i, cmp)
+        }
+        for range lazyCount {
+            v := s[0]
+            last := len(s) - 1
+            s[0] = s[last]
+            s = s[:last]
+            siftDown(s, 0, cmp)
+            if !yield(v) {
+                return
+            }
+        }
+    }
+
+    slices.SortFunc(s, cmp)
+    for _, each := range s {
+        if !yield(each) {
+            return
+        }
+    }
+}
+
+// siftDown restores the min-heap property of h for the subtree rooted at i.
+func siftDown[T any](h []T, i int, cmp func(T, T) int) {
+    for {
+        smallest := i
+        l, r := 2*i+1, 2*i+2
+        if l < len(h) && cmp(h[l], h[smallest]) < 0 {
+            smallest = l
+        }
+        if r < len(h) && cmp(h[r], h[smallest]) < 0 {
+            smallest = r
+        }
+        if smallest == i {
+            return
+        }
+        h[i], h[smallest] = h[smallest], h[i]
+        i = smallest
+    }
+}
+
+type stableEntry[T any] struct {
+    idx int
+    v   T
+}
+
+// stableCmp breaks the ties of cmp by the original position of the elements, so any sort algorithm becomes stable.
+func stableCmp[T any](cmp func(T, T) int) func(stableEntry[T], stableEntry[T]) int {
+    return func(a, b stableEntry[T]) int {
+        if c := cmp(a.v, b.v); c != 0 {
+            return c
+        }
+        return a.idx - b.idx
+    }
+}
+
+// TopK returns an iterator that yields the k largest elements of the input iterator in descending order.
+// Unlike Order(iterator, true).Take(k), it only keeps k elements in memory instead of sorting the whole input.
+// For example:
+//
+//  iterator := goiter.TopK(goiter.SliceElems([]int{5, 1, 4, 2, 3}), 2)     // iterator will yield 5 4
+//
+// If k is less than or equal to 0, it yields nothing.
+func TopK[TIter SeqX[T], T cmp.Ordered](iterator TIter, k int) Iterator[T] {
+    return TopKBy(iterator, k, cmp.Compare[T])
+}
+
+// BottomK is like TopK, but it yields the k smallest elements of the input iterator in ascending order.
+func BottomK[TIter SeqX[T], T cmp.Ordered](iterator TIter, k int) Iterator[T] {
+    return TopKBy(iterator, k, func(a, b T) int {
+        return cmp.Compare(b, a)
+    })
+}
+
+// TopKBy accepts a comparison function and returns an iterator that yields the k largest elements according to the comparison function,
+// the elements are arranged in descending order. To get the k smallest elements, just reverse the comparison function.
+// If k is less than or equal to 0, it yields nothing.
+func TopKBy[TIter SeqX[T], T any](
+    iterator TIter,
+    k int,
+    cmp func(T, T) int,
+) Iterator[T] {
+    if k <= 0 {
+        return Empty[T]()
+    }
+
+    return func(yield func(T) bool) {
+        for _, each := range selectTopK(iterator, k, cmp) {
+            if !yield(each) {
                 return
             }
         }
     }
 }
+
+// selectTopK keeps the k largest elements in a bounded min-heap, and returns them in descending order.
+func selectTopK[TIter SeqX[T], T any](iterator TIter, k int, cmp func(T, T) int) []T {
+    h := make([]T, 0, min(k, 1024))
+    for v := range iterator {
+        if len(h) < k {
+            h = append(h, v)
+            for i := len(h) - 1; i > 0; {
+                parent := (i - 1) / 2
+                if cmp(h[i], h[parent]) >= 0 {
+                    break
+                }
+                h[i], h[parent] = h[parent], h[i]
+                i = parent
+            }
+        } else if cmp(v, h[0]) > 0 {
+            h[0] = v
+            siftDown(h, 0, cmp)
+        }
+    }
+
+    slices.SortFunc(h, func(a, b T) int {
+        return cmp(b, a)
+    })
+    return h
+}
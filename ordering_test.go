@@ -213,3 +213,171 @@ func TestStableOrderBy2(t *testing.T) {
         t.Fatal("expect:", expect, "actual:", actual)
     }
 }
+
+func TestTopK(t *testing.T) {
+    actual := make([]int, 0, 3)
+    for v := range TopK(SliceElems([]int{5, 1, 4, 2, 3, 4}), 3) {
+        actual = append(actual, v)
+    }
+    expect := []int{5, 4, 4}
+    if !slices.Equal(expect, actual) {
+        t.Fatal("expect:", expect, "actual:", actual)
+    }
+
+    actual = make([]int, 0, 3)
+    for v := range TopK(SliceElems([]int{2, 1}), 3) {
+        actual = append(actual, v)
+    }
+    expect = []int{2, 1}
+    if !slices.Equal(expect, actual) {
+        t.Fatal("expect:", expect, "actual:", actual)
+    }
+
+    if TopK(SliceElems([]int{1, 2}), 0).Count() != 0 {
+        t.Fatal("expect TopK with k = 0 to yield nothing")
+    }
+
+    // won't panic
+    for _ = range TopK(SliceElems([]int{1, 2, 3}), 2) {
+        break
+    }
+}
+
+func TestBottomK(t *testing.T) {
+    actual := make([]int, 0, 3)
+    for v := range BottomK(Range(100, 1), 3) {
+        actual = append(actual, v)
+    }
+    expect := []int{1, 2, 3}
+    if !slices.Equal(expect, actual) {
+        t.Fatal("expect:", expect, "actual:", actual)
+    }
+}
+
+func TestTopKBy(t *testing.T) {
+    type person struct {
+        name string
+        age  int
+    }
+    input := []person{{"bob", 20}, {"eve", 18}, {"alice", 22}, {"john", 19}}
+    actual := make([]string, 0, 2)
+    for v := range TopKBy(SliceElems(input), 2, func(a, b person) int { return cmp.Compare(b.age, a.age) }) {
+        actual = append(actual, v.name)
+    }
+    expect := []string{"eve", "john"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal("expect:", expect, "actual:", actual)
+    }
+}
+
+func TestOrderBy_Partial(t *testing.T) {
+    input := make([]int, 0, 10000)
+    for i := range 10000 {
+        input = append(input, (i*7919)%10000)
+    }
+
+    // case 1: taking the first few elements doesn't sort the whole input
+    comparisons := 0
+    cmpFunc := func(a, b int) int {
+        comparisons++
+        return cmp.Compare(a, b)
+    }
+    actual := make([]int, 0, 10)
+    for v := range OrderBy(SliceElems(input), cmpFunc).Take(10) {
+        actual = append(actual, v)
+    }
+    expect := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+    if !slices.Equal(expect, actual) {
+        t.Fatal("expect:", expect, "actual:", actual)
+    }
+    if comparisons > 50000 {
+        t.Fatal("too many comparisons:", comparisons)
+    }
+
+    // case 2: consuming the whole iterator still yields all elements in order
+    actual = make([]int, 0, len(input))
+    for v := range OrderBy(SliceElems(input), cmp.Compare[int]) {
+        actual = append(actual, v)
+    }
+    if len(actual) != len(input) || !slices.IsSorted(actual) {
+        t.Fatal("expect all elements to be sorted")
+    }
+
+    // case 3: stability is kept across the lazy phase and the sorting phase
+    type pair struct {
+        key int
+        seq int
+    }
+    pairs := make([]pair, 0, 5000)
+    for i := range 5000 {
+        pairs = append(pairs, pair{key: (i * 31) % 7, seq: i})
+    }
+    actualPairs := make([]pair, 0, len(pairs))
+    for v := range StableOrderBy(SliceElems(pairs), func(a, b pair) int { return cmp.Compare(a.key, b.key) }) {
+        actualPairs = append(actualPairs, v)
+    }
+    expectPairs := slices.Clone(pairs)
+    slices.SortStableFunc(expectPairs, func(a, b pair) int { return cmp.Compare(a.key, b.key) })
+    if !slices.Equal(expectPairs, actualPairs) {
+        t.Fatal("expect stable ordering")
+    }
+}
+
+func TestIsSorted(t *testing.T) {
+    if !IsSorted(SliceElems([]int{1, 2, 2, 3})) || !IsSorted(Empty[int]()) {
+        t.Fatal("expect sorted")
+    }
+    if IsSorted(SliceElems([]int{1, 3, 2})) {
+        t.Fatal("expect not sorted")
+    }
+
+    // it stops at the first element out of order
+    consumed := 0
+    iterator := Transform(Counter(0), func(v int) int {
+        consumed++
+        return -v
+    })
+    if IsSorted(iterator) || consumed != 2 {
+        t.Fatal("expect not sorted after consuming 2 elements, consumed:", consumed)
+    }
+}
+
+func TestIsSortedBy(t *testing.T) {
+    desc := func(a, b string) int { return cmp.Compare(b, a) }
+    if !IsSortedBy(SliceElems([]string{"c", "b", "a"}), desc) {
+        t.Fatal("expect sorted")
+    }
+    if IsSortedBy(SliceElems([]string{"c", "a", "b"}), desc) {
+        t.Fatal("expect not sorted")
+    }
+}
+
+func TestIsSorted2V1(t *testing.T) {
+    if !IsSorted2V1(Swap(Slice([]string{"a", "b", "c"}))) || !IsSorted2V1(Slice([]string{"c", "b"})) {
+        t.Fatal("expect sorted")
+    }
+    if IsSorted2V1(Swap(Slice([]string{"b", "a"}))) {
+        t.Fatal("expect not sorted")
+    }
+}
+
+func TestIsSorted2V2(t *testing.T) {
+    if !IsSorted2V2(Slice([]string{"a", "b", "c"})) {
+        t.Fatal("expect sorted")
+    }
+    if IsSorted2V2(Slice([]string{"b", "a"})) {
+        t.Fatal("expect not sorted")
+    }
+}
+
+func TestIsSorted2By(t *testing.T) {
+    cmpFunc := func(a, b *Combined[int, string]) int {
+        return cmp.Compare(len(a.V2), len(b.V2))
+    }
+    if !IsSorted2By(Slice([]string{"a", "bb", "cc", "ddd"}), cmpFunc) {
+        t.Fatal("expect sorted")
+    }
+    if IsSorted2By(Slice([]string{"aa", "b"}), cmpFunc) {
+        t.Fatal("expect not sorted")
+    }
+}
@@ -1,12 +1,22 @@
 package goiter
 
 import (
+    "container/list"
     "iter"
+    "os"
+    "runtime"
+    "sync"
     "sync/atomic"
 )
 
 // Cache returns an iterator that caches the values of the input iterator.
-func Cache[TIter SeqX[T], T any](it TIter) Iterator[T] {
+// The memory held by the cache can be limited by WithMemoryBudget, see BufferOption. When the budget is exceeded,
+// the iteration stops without caching anything, or with WithSpill, all the values are moved to a file for replaying.
+func Cache[TIter SeqX[T], T any](it TIter, opts ...BufferOption[T]) Iterator[T] {
+    if budget := newBufferBudget[T](opts); budget != nil {
+        return Iterator[T](cacheWithin(iter.Seq[T](it), budget))
+    }
+
     var cached []T
     var cacheFlag int32
 
@@ -52,8 +62,121 @@ func Cache[TIter SeqX[T], T any](it TIter) Iterator[T] {
     }
 }
 
+// cacheWithin is Cache with a memory budget. The values are cached in memory until they exceed the budget,
+// then they are moved to a spill file, and the rest of the values are appended to the file.
+// Like Cache, the values are only cached when an iteration completes.
+// Each iteration reports its own error when it ends, and the spill file is removed when the iterator is garbage collected.
+func cacheWithin[T any](it iter.Seq[T], budget *bufferBudget[T]) iter.Seq[T] {
+    c := &budgetCache[T]{}
+    runtime.SetFinalizer(c, func(c *budgetCache[T]) {
+        if c.file != "" {
+            _ = os.Remove(c.file)
+        }
+    })
+
+    return func(yield func(T) bool) {
+        err := c.iterate(it, budget, yield)
+        c.mu.Lock()
+        defer c.mu.Unlock()
+        budget.report(err)
+    }
+}
+
+type budgetCache[T any] struct {
+    mu     sync.Mutex
+    values []T
+    // file is the spill file holding the values, or empty if they are in memory.
+    file string
+    done bool
+}
+
+func (c *budgetCache[T]) store(values []T, file string) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if c.done {
+        // another iteration has completed first.
+        if file != "" {
+            _ = os.Remove(file)
+        }
+        return
+    }
+    c.values, c.file, c.done = values, file, true
+}
+
+func (c *budgetCache[T]) iterate(it iter.Seq[T], budget *bufferBudget[T], yield func(T) bool) error {
+    c.mu.Lock()
+    values, file, done := c.values, c.file, c.done
+    c.mu.Unlock()
+    if done {
+        if file != "" {
+            return replayFile(file, budget.codec, yield)
+        }
+        for _, v := range values {
+            if !yield(v) {
+                return nil
+            }
+        }
+        return nil
+    }
+
+    var buffer []T
+    used := 0
+    var spill *spillFile[T]
+    completed := false
+    defer func() {
+        if spill != nil && !completed {
+            spill.discard()
+        }
+    }()
+    for v := range it {
+        if spill == nil {
+            used += budget.sizeOf(v)
+            if used <= budget.bytes {
+                buffer = append(buffer, v)
+            } else if budget.codec == nil {
+                return budget.exceeded()
+            } else {
+                var err error
+                if spill, err = newSpillFile(budget.codec, budget.dir); err != nil {
+                    return err
+                }
+                for _, each := range buffer {
+                    if err := spill.write(each); err != nil {
+                        return err
+                    }
+                }
+                buffer = nil
+            }
+        }
+        if spill != nil {
+            if err := spill.write(v); err != nil {
+                return err
+            }
+        }
+        if !yield(v) {
+            return nil
+        }
+    }
+
+    if spill == nil {
+        c.store(buffer, "")
+        return nil
+    }
+    if err := spill.close(); err != nil {
+        return err
+    }
+    completed = true
+    c.store(nil, spill.name)
+    return nil
+}
+
 // Cache2 is iter.Seq2 version of Cache.
-func Cache2[TIter Seq2X[T1, T2], T1 any, T2 any](it TIter) Iterator2[T1, T2] {
+// The memory can be limited by the options of element type *Combined[T1, T2], see BufferOption.
+func Cache2[TIter Seq2X[T1, T2], T1 any, T2 any](it TIter, opts ...BufferOption[*Combined[T1, T2]]) Iterator2[T1, T2] {
+    if budget := newBufferBudget[*Combined[T1, T2]](opts); budget != nil {
+        return uncombineSeq(cacheWithin(combineSeq(iter.Seq2[T1, T2](it)), budget))
+    }
+
     var cached []*Combined[T1, T2]
     var cacheFlag int32
 
@@ -101,3 +224,266 @@ func Cache2[TIter Seq2X[T1, T2], T1 any, T2 any](it TIter) Iterator2[T1, T2] {
         }
     }
 }
+
+// CacheN is like Cache, but it only caches the first n values of the input iterator, so the memory usage is bounded.
+// The subsequent iterations replay the cached values first, and only iterate the input iterator again for the rest of the values,
+// which are recomputed every time. So if the consumer usually only needs the first few values, such as CacheN(it, 10).Take(10),
+// the input iterator is not iterated again at all.
+// If n is less than or equal to 0, nothing is cached and the input iterator is returned as is.
+// To look up the recently used pairs of a keyed iterator within a bounded memory, see LookupLRU.
+func CacheN[TIter SeqX[T], T any](it TIter, n int) Iterator[T] {
+    if n <= 0 {
+        return Iterator[T](it)
+    }
+
+    var mu sync.Mutex
+    var head []T
+    cached := false
+    exhausted := false
+    store := func(h []T, end bool) {
+        mu.Lock()
+        defer mu.Unlock()
+        if !cached {
+            head = h
+            cached = true
+        }
+        exhausted = exhausted || end
+    }
+
+    return func(yield func(T) bool) {
+        mu.Lock()
+        h, c, e := head, cached, exhausted
+        mu.Unlock()
+
+        if c {
+            for _, v := range h {
+                if !yield(v) {
+                    return
+                }
+            }
+            if e {
+                return
+            }
+            idx := 0
+            for v := range it {
+                idx++
+                if idx <= n {
+                    continue
+                }
+                if !yield(v) {
+                    return
+                }
+            }
+            return
+        }
+
+        temp := make([]T, 0, min(n, 1024))
+        for v := range it {
+            if len(temp) < n {
+                temp = append(temp, v)
+                if len(temp) == n {
+                    store(temp, false)
+                }
+            }
+            if !yield(v) {
+                return
+            }
+        }
+        store(temp, true)
+    }
+}
+
+// KeyedCache is a cache of a keyed iterator that can also be queried by key, see CacheKeyed function for more details.
+type KeyedCache[K comparable, V any] struct {
+    mu     sync.Mutex
+    source iter.Seq2[K, V]
+    done   bool
+    cached []*Combined[K, V]
+    index  map[K]int
+}
+
+// CacheKeyed is like Cache2 function, but it returns a KeyedCache, whose Iterator method returns the cached iterator.
+// After the first full pass, the cached data can also be queried by Lookup and Len methods without a separate ToMap collection.
+// Lookup and Len perform the first full pass themselves if it has not happened yet.
+// For example:
+//
+//  users := goiter.CacheKeyed(loadUsers())   // loadUsers returns an iterator yields (id, user) pairs
+//  for id, user := range users.Iterator() {
+//      ...
+//  }
+//  u, ok := users.Lookup(42)
+func CacheKeyed[TIter Seq2X[K, V], K comparable, V any](it TIter) *KeyedCache[K, V] {
+    return &KeyedCache[K, V]{
+        source: iter.Seq2[K, V](it),
+    }
+}
+
+// Iterator returns an iterator that yields the cached pairs, or the pairs of the input iterator if the first full pass has not happened yet.
+func (c *KeyedCache[K, V]) Iterator() Iterator2[K, V] {
+    return func(yield func(K, V) bool) {
+        if cached, ok := c.snapshot(); ok {
+            for _, v := range cached {
+                if !yield(v.V1, v.V2) {
+                    return
+                }
+            }
+            return
+        }
+
+        cTemp := make([]*Combined[K, V], 0)
+        for k, v := range c.source {
+            if !yield(k, v) {
+                return
+            }
+            cTemp = append(cTemp, &Combined[K, V]{V1: k, V2: v})
+        }
+        c.store(cTemp)
+    }
+}
+
+// Lookup returns the value of the key, if the key appears more than once, the last value is returned.
+func (c *KeyedCache[K, V]) Lookup(key K) (V, bool) {
+    c.fill()
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if idx, ok := c.index[key]; ok {
+        return c.cached[idx].V2, true
+    }
+    var zero V
+    return zero, false
+}
+
+// Len returns the number of distinct keys of the cached data.
+func (c *KeyedCache[K, V]) Len() int {
+    c.fill()
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return len(c.index)
+}
+
+func (c *KeyedCache[K, V]) snapshot() ([]*Combined[K, V], bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.cached, c.done
+}
+
+func (c *KeyedCache[K, V]) fill() {
+    if _, ok := c.snapshot(); ok {
+        return
+    }
+    for _, _ = range c.Iterator() {
+    }
+}
+
+func (c *KeyedCache[K, V]) store(cached []*Combined[K, V]) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if c.done {
+        return
+    }
+    index := make(map[K]int, len(cached))
+    for i, v := range cached {
+        index[v.V1] = i
+    }
+    c.cached = cached
+    c.index = index
+    c.done = true
+}
+
+// LRULookup is a bounded lookup cache of a keyed iterator with least-recently-used eviction, see LookupLRU function for more details.
+type LRULookup[K comparable, V any] struct {
+    mu      sync.Mutex
+    source  iter.Seq2[K, V]
+    n       int
+    entries *list.List
+    index   map[K]*list.Element
+}
+
+// LookupLRU returns a lookup cache of the input iterator that keeps at most n pairs in memory.
+// Unlike Cache, CacheN and CacheKeyed, it doesn't cache the iteration: the pairs can't be replayed from it,
+// since the evicted ones are gone, it only speeds up finding a value by its key.
+// When a new key is cached and the cache is full, the least recently used pair is evicted,
+// where a pair is used when it is cached, passes through the Iterator method again or is looked up.
+// Lookup answers from the cache if the key is cached, otherwise it iterates the input iterator to find the key,
+// and the pairs passed along the way are cached as well.
+// So the memory usage is bounded by n, while the keys that are looked up frequently are answered without iterating the input iterator.
+// For example:
+//
+//  users := goiter.LookupLRU(loadUsers(), 1000)   // loadUsers returns an iterator yields (id, user) pairs
+//  u, ok := users.Lookup(42)
+//
+// If n is less than or equal to 0, nothing is cached, and every Lookup iterates the input iterator.
+func LookupLRU[TIter Seq2X[K, V], K comparable, V any](it TIter, n int) *LRULookup[K, V] {
+    return &LRULookup[K, V]{
+        source:  iter.Seq2[K, V](it),
+        n:       n,
+        entries: list.New(),
+        index:   map[K]*list.Element{},
+    }
+}
+
+// Iterator returns an iterator that yields the pairs of the input iterator, and caches them as they pass for the later lookups.
+// It always iterates the input iterator, the pairs are never yielded from the cache.
+func (c *LRULookup[K, V]) Iterator() Iterator2[K, V] {
+    return func(yield func(K, V) bool) {
+        for k, v := range c.source {
+            c.put(k, v)
+            if !yield(k, v) {
+                return
+            }
+        }
+    }
+}
+
+// Lookup returns the value of the key. If the key appears more than once in the input iterator, the last value is returned,
+// unless the key is cached, then the cached value is returned, which is the last one seen when it was cached.
+func (c *LRULookup[K, V]) Lookup(key K) (V, bool) {
+    c.mu.Lock()
+    if elem, ok := c.index[key]; ok {
+        c.entries.MoveToFront(elem)
+        v := elem.Value.(*Combined[K, V]).V2
+        c.mu.Unlock()
+        return v, true
+    }
+    c.mu.Unlock()
+
+    var found V
+    ok := false
+    for k, v := range c.source {
+        if k == key {
+            found, ok = v, true
+        }
+        c.put(k, v)
+    }
+    if ok {
+        // the key might have been evicted by the pairs after it
+        c.put(key, found)
+    }
+    return found, ok
+}
+
+// Len returns the number of cached pairs, which is at most n.
+func (c *LRULookup[K, V]) Len() int {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.entries.Len()
+}
+
+func (c *LRULookup[K, V]) put(k K, v V) {
+    if c.n <= 0 {
+        return
+    }
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if elem, ok := c.index[k]; ok {
+        elem.Value.(*Combined[K, V]).V2 = v
+        c.entries.MoveToFront(elem)
+        return
+    }
+    c.index[k] = c.entries.PushFront(&Combined[K, V]{V1: k, V2: v})
+    if c.entries.Len() > c.n {
+        oldest := c.entries.Back()
+        c.entries.Remove(oldest)
+        delete(c.index, oldest.Value.(*Combined[K, V]).V1)
+    }
+}
@@ -0,0 +1,175 @@
+package goiter
+
+import (
+    "bytes"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "reflect"
+    "strings"
+    "sync"
+)
+
+// ProjectFields returns an iterator that converts each struct value of the input iterator to a struct of type U,
+// by copying the fields of T to the fields of U with the same name.
+// The name of a field is its `goiter` tag if it has one, or the field name otherwise, fields tagged with `goiter:"-"` are ignored.
+// A field is copied only if its type is assignable to the type of the target field, the other fields of U keep their zero values.
+// The field mapping is computed once per pair of types and cached.
+// It panics if T or U is not a struct type.
+// For example:
+//
+//  type User struct {
+//      ID       int
+//      Name     string
+//      Password string
+//  }
+//  type UserDTO struct {
+//      ID   int    `goiter:"ID"`
+//      Name string
+//  }
+//  iterator := goiter.ProjectFields[UserDTO](users)
+func ProjectFields[U any, TIter SeqX[T], T any](iterator TIter) Iterator[U] {
+    mapping := projectionOf(reflect.TypeFor[T](), reflect.TypeFor[U]())
+    return func(yield func(U) bool) {
+        for v := range iterator {
+            var u U
+            src := reflect.ValueOf(&v).Elem()
+            dst := reflect.ValueOf(&u).Elem()
+            for _, m := range mapping {
+                dst.Field(m[1]).Set(src.Field(m[0]))
+            }
+            if !yield(u) {
+                return
+            }
+        }
+    }
+}
+
+type projectionKey struct {
+    from reflect.Type
+    to   reflect.Type
+}
+
+// projections caches the field mappings of ProjectFields, keyed by projectionKey.
+var projections sync.Map
+
+// projectionOf returns the pairs of (source field index, target field index) to copy from type from to type to.
+func projectionOf(from reflect.Type, to reflect.Type) [][2]int {
+    key := projectionKey{from: from, to: to}
+    if m, ok := projections.Load(key); ok {
+        return m.([][2]int)
+    }
+
+    if from.Kind() != reflect.Struct || to.Kind() != reflect.Struct {
+        panic(fmt.Sprintf("goiter: ProjectFields requires struct types, got %v and %v", from, to))
+    }
+    sourceFields := structFieldsByName(from)
+    mapping := [][2]int{}
+    for name, j := range structFieldsByName(to) {
+        i, ok := sourceFields[name]
+        if !ok || !from.Field(i).Type.AssignableTo(to.Field(j).Type) {
+            continue
+        }
+        mapping = append(mapping, [2]int{i, j})
+    }
+    projections.Store(key, mapping)
+    return mapping
+}
+
+// structFieldsByName returns the indexes of the exported fields of a struct type, keyed by their names, see ProjectFields for the naming rule.
+func structFieldsByName(typ reflect.Type) map[string]int {
+    fields := map[string]int{}
+    for i := 0; i < typ.NumField(); i++ {
+        field := typ.Field(i)
+        if !field.IsExported() {
+            continue
+        }
+        name := field.Name
+        if tag, ok := field.Tag.Lookup("goiter"); ok {
+            tagName, _, _ := strings.Cut(tag, ",")
+            if tagName == "-" {
+                continue
+            }
+            if tagName != "" {
+                name = tagName
+            }
+        }
+        fields[name] = i
+    }
+    return fields
+}
+
+// MaskFunc computes the masked value of a sensitive string field.
+type MaskFunc func(string) string
+
+// RedactMask is a MaskFunc that replaces the value with "[REDACTED]", empty values are kept empty.
+func RedactMask(s string) string {
+    if s == "" {
+        return s
+    }
+    return "[REDACTED]"
+}
+
+// HashMask is a MaskFunc that replaces the value with the hex encoded SHA-256 hash of it,
+// so the masked values can still be joined and counted.
+// It is not a privacy control: the hash is unsalted, so the values from a small or guessable space, such as emails or phone numbers,
+// can be recovered by hashing the candidates. Use HMACMask to pseudonymize personal data.
+func HashMask(s string) string {
+    sum := sha256.Sum256([]byte(s))
+    return hex.EncodeToString(sum[:])
+}
+
+// HMACMask returns a MaskFunc that replaces the value with the hex encoded HMAC-SHA256 of it keyed by key,
+// so like HashMask, the masked values can still be joined and counted, but they cannot be recovered by hashing the candidates
+// without the key. The key should be random, at least 32 bytes long, and kept secret.
+func HMACMask(key []byte) MaskFunc {
+    key = bytes.Clone(key)
+    return func(s string) string {
+        mac := hmac.New(sha256.New, key)
+        mac.Write([]byte(s))
+        return hex.EncodeToString(mac.Sum(nil))
+    }
+}
+
+// MaskFields returns an iterator that masks the sensitive string fields of each struct value of the input iterator.
+// The rules map the field names to the MaskFunc applied to them, the field names follow the same rule as ProjectFields.
+// It panics if T is not a struct type, or a rule refers to a field that doesn't exist or is not a string.
+// For example:
+//
+//  iterator := goiter.MaskFields(users, map[string]goiter.MaskFunc{
+//      "Email": goiter.HMACMask(key),
+//      "Token": goiter.RedactMask,
+//  })
+func MaskFields[TIter SeqX[T], T any](iterator TIter, rules map[string]MaskFunc) Iterator[T] {
+    typ := reflect.TypeFor[T]()
+    if typ.Kind() != reflect.Struct {
+        panic(fmt.Sprintf("goiter: MaskFields requires a struct type, got %v", typ))
+    }
+    fields := structFieldsByName(typ)
+    type maskRule struct {
+        field int
+        mask  MaskFunc
+    }
+    maskRules := make([]maskRule, 0, len(rules))
+    for name, mask := range rules {
+        idx, ok := fields[name]
+        if !ok || typ.Field(idx).Type.Kind() != reflect.String {
+            panic(fmt.Sprintf("goiter: MaskFields has no string field %q in %v", name, typ))
+        }
+        maskRules = append(maskRules, maskRule{field: idx, mask: mask})
+    }
+
+    return func(yield func(T) bool) {
+        for v := range iterator {
+            rv := reflect.ValueOf(&v).Elem()
+            for _, rule := range maskRules {
+                field := rv.Field(rule.field)
+                field.SetString(rule.mask(field.String()))
+            }
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
@@ -0,0 +1,91 @@
+package goiter
+
+import (
+    "fmt"
+    "slices"
+    "testing"
+)
+
+func TestProjectFields(t *testing.T) {
+    type user struct {
+        ID       int
+        Name     string
+        Email    string `goiter:"mail"`
+        Password string `goiter:"-"`
+        Age      int
+    }
+    type userDTO struct {
+        ID       int
+        Name     string
+        Mail     string `goiter:"mail"`
+        Password string
+        Age      string
+    }
+
+    users := Items(
+        user{ID: 1, Name: "alice", Email: "alice@example.com", Password: "secret", Age: 20},
+        user{ID: 2, Name: "bob", Email: "bob@example.com", Password: "secret", Age: 3},
+    )
+    actual := ProjectFields[userDTO](users).ToSlice()
+    expect := []userDTO{
+        {ID: 1, Name: "alice", Mail: "alice@example.com"},
+        {ID: 2, Name: "bob", Mail: "bob@example.com"},
+    }
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _ = range ProjectFields[userDTO](users) {
+        break
+    }
+
+    defer func() {
+        if r := recover(); r == nil {
+            t.Fatal("expect panic for non-struct types")
+        }
+    }()
+    ProjectFields[userDTO](Items(1, 2))
+}
+
+func TestMaskFields(t *testing.T) {
+    type account struct {
+        Name  string
+        Email string `goiter:"mail"`
+        Token string
+        Age   int
+    }
+
+    accounts := Items(
+        account{Name: "alice", Email: "alice@example.com", Token: "t1", Age: 20},
+        account{Name: "bob", Email: "alice@example.com", Age: 3},
+    )
+    actual := MaskFields(accounts, map[string]MaskFunc{
+        "mail":  HashMask,
+        "Token": RedactMask,
+    }).ToSlice()
+    if len(actual) != 2 || actual[0].Name != "alice" || actual[0].Age != 20 || actual[0].Token != "[REDACTED]" || actual[1].Token != "" {
+        t.Fatal(fmt.Sprintf("unexpected masked values: %v", actual))
+    }
+    if actual[0].Email == "alice@example.com" || len(actual[0].Email) != 64 || actual[0].Email != actual[1].Email {
+        t.Fatal(fmt.Sprintf("expect hashed emails, actual: %v", actual))
+    }
+
+    keyed := MaskFields(accounts, map[string]MaskFunc{"mail": HMACMask([]byte("secret"))}).ToSlice()
+    if len(keyed[0].Email) != 64 || keyed[0].Email != keyed[1].Email || keyed[0].Email == actual[0].Email {
+        t.Fatal(fmt.Sprintf("expect keyed hashes different from the plain ones, actual: %v", keyed))
+    }
+    if other := HMACMask([]byte("other"))("alice@example.com"); other == keyed[0].Email {
+        t.Fatal("expect different keys to produce different hashes")
+    }
+
+    for _ = range MaskFields(accounts, map[string]MaskFunc{"Token": RedactMask}) {
+        break
+    }
+
+    defer func() {
+        if r := recover(); r == nil {
+            t.Fatal("expect panic for non-string field")
+        }
+    }()
+    MaskFields(accounts, map[string]MaskFunc{"Age": RedactMask})
+}
@@ -0,0 +1,89 @@
+package goiter
+
+import (
+    "math/rand/v2"
+)
+
+// RandomInts returns an infinite iterator that yields random integers between min and max inclusively, drawn from r.
+// Use a rand.Rand with a fixed seed to get a reproducible sequence, if r is nil, the global random source is used.
+// If min is greater than max, it yields nothing.
+// For example:
+//
+//  r := rand.New(rand.NewPCG(1, 2))
+//  goiter.Take(goiter.RandomInts(r, 1, 6), 3)   // will yield 3 dice rolls, the same ones every run
+func RandomInts(r *rand.Rand, min, max int) Iterator[int] {
+    if min > max {
+        return Empty[int]()
+    }
+    span := uint64(max-min) + 1
+    return func(yield func(int) bool) {
+        for {
+            var offset uint64
+            if span == 0 {
+                // the range covers all the int values
+                offset = randUint64(r)
+            } else {
+                offset = randUint64N(r, span)
+            }
+            if !yield(min + int(offset)) {
+                return
+            }
+        }
+    }
+}
+
+// RandomFloats returns an infinite iterator that yields random floating-point numbers in [min, max), drawn from r.
+// If r is nil, the global random source is used. If min is not less than max, it yields nothing.
+func RandomFloats(r *rand.Rand, min, max float64) Iterator[float64] {
+    if !(min < max) {
+        return Empty[float64]()
+    }
+    return func(yield func(float64) bool) {
+        for {
+            v := min + randFloat64(r)*(max-min)
+            if v >= max {
+                // rounding may produce max
+                v = min
+            }
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+// RandomPick returns an infinite iterator that yields the items picked randomly with replacement, drawn from r.
+// If r is nil, the global random source is used. If items is empty, it yields nothing.
+func RandomPick[S ~[]T, T any](r *rand.Rand, items S) Iterator[T] {
+    if len(items) == 0 {
+        return Empty[T]()
+    }
+    return func(yield func(T) bool) {
+        for {
+            if !yield(items[randUint64N(r, uint64(len(items)))]) {
+                return
+            }
+        }
+    }
+}
+
+func randUint64(r *rand.Rand) uint64 {
+    if r == nil {
+        return rand.Uint64()
+    }
+    return r.Uint64()
+}
+
+func randUint64N(r *rand.Rand, n uint64) uint64 {
+    if r == nil {
+        return rand.Uint64N(n)
+    }
+    return r.Uint64N(n)
+}
+
+func randFloat64(r *rand.Rand) float64 {
+    if r == nil {
+        return rand.Float64()
+    }
+    return r.Float64()
+}
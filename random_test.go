@@ -0,0 +1,59 @@
+package goiter
+
+import (
+    "fmt"
+    "math"
+    "math/rand/v2"
+    "slices"
+    "testing"
+)
+
+func TestRandomInts(t *testing.T) {
+    first := Take(RandomInts(rand.New(rand.NewPCG(1, 2)), 1, 6), 100).ToSlice()
+    second := Take(RandomInts(rand.New(rand.NewPCG(1, 2)), 1, 6), 100).ToSlice()
+    if !slices.Equal(first, second) {
+        t.Fatal("expect the same sequence from the same seed")
+    }
+    seen := map[int]bool{}
+    for _, v := range first {
+        if v < 1 || v > 6 {
+            t.Fatal(fmt.Sprintf("expect values in [1, 6], actual: %d", v))
+        }
+        seen[v] = true
+    }
+    if len(seen) != 6 {
+        t.Fatal(fmt.Sprintf("expect all of 1 to 6 to appear, actual: %v", seen))
+    }
+
+    if Count(Take(RandomInts(nil, math.MinInt, math.MaxInt), 3)) != 3 {
+        t.Fatal("expect values for the full int range")
+    }
+    if Count(RandomInts(nil, 2, 1)) != 0 {
+        t.Fatal("expect nothing when min is greater than max")
+    }
+}
+
+func TestRandomFloats(t *testing.T) {
+    for _, v := range Take(RandomFloats(rand.New(rand.NewPCG(1, 2)), -1, 1), 100).ToSlice() {
+        if v < -1 || v >= 1 {
+            t.Fatal(fmt.Sprintf("expect values in [-1, 1), actual: %v", v))
+        }
+    }
+    if Count(RandomFloats(nil, 1, 1)) != 0 {
+        t.Fatal("expect nothing when min is not less than max")
+    }
+}
+
+func TestRandomPick(t *testing.T) {
+    items := []string{"a", "b", "c"}
+    seen := map[string]bool{}
+    for _, v := range Take(RandomPick(rand.New(rand.NewPCG(1, 2)), items), 100).ToSlice() {
+        seen[v] = true
+    }
+    if len(seen) != 3 {
+        t.Fatal(fmt.Sprintf("expect all items to be picked, actual: %v", seen))
+    }
+    if Count(RandomPick(nil, []int{})) != 0 {
+        t.Fatal("expect nothing for empty items")
+    }
+}
@@ -3,6 +3,8 @@ package goiter
 import (
     "iter"
     "math"
+    "math/big"
+    "time"
 )
 
 type GeneratorFunc[T any] func() (T, bool)
@@ -14,6 +16,14 @@ type TInt interface {
     ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
 }
 
+type TFloat interface {
+    ~float32 | ~float64
+}
+
+type Number interface {
+    TInt | TFloat
+}
+
 // Range returns an iterator that yields a sequence of integers forward or backward from start to end, incrementing/decrementing by 1.
 // to be specific, the second parameter "end" is inclusive.
 // for example:
@@ -74,6 +84,129 @@ func RangeStep[T TInt, S TInt](start, end T, stepSize S) Iterator[T] {
     }
 }
 
+// RangeFloat returns an iterator that yields n evenly spaced numbers from start to stop inclusively, like numpy's linspace.
+// Each value is computed as start + i*step rather than accumulated, so there is no cumulative floating-point error, and the last value is exactly stop.
+// If n is 1, it yields start only, and if n is not positive, it yields nothing.
+// For example:
+//
+//  goiter.RangeFloat(0, 1, 5)   // will yield 0, 0.25, 0.5, 0.75, 1
+func RangeFloat(start, stop float64, n int) Iterator[float64] {
+    if n <= 0 {
+        return Empty[float64]()
+    }
+
+    step := 0.0
+    if n > 1 {
+        step = (stop - start) / float64(n-1)
+    }
+    return func(yield func(float64) bool) {
+        for i := 0; i < n; i++ {
+            v := start + float64(i)*step
+            if i == n-1 && n > 1 {
+                v = stop
+            }
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+// RangeBig is like RangeStep function, but for the integers of arbitrary size, such as the ranges beyond int64.
+// The same as RangeStep, stop is inclusive, and step must be positive regardless of the direction, otherwise nothing is yielded.
+// The arguments are copied, so changing them after calling RangeBig doesn't affect the iteration.
+// By default, each yielded value is newly allocated. If the optional reuseBuffer parameter is true, the same big.Int is reused for every value to avoid allocations,
+// in this case you should copy the yielded value if you need to keep it, and must not modify it.
+// For example:
+//
+//  start, _ := new(big.Int).SetString("18446744073709551615", 10)
+//  goiter.RangeBig(start, new(big.Int).Add(start, big.NewInt(2)), big.NewInt(1))  // will yield 18446744073709551615, 18446744073709551616, 18446744073709551617
+func RangeBig(start, stop, step *big.Int, reuseBuffer ...bool) Iterator[*big.Int] {
+    if start == nil || stop == nil || step == nil || step.Sign() <= 0 {
+        return Empty[*big.Int]()
+    }
+    reuse := len(reuseBuffer) > 0 && reuseBuffer[0]
+    from := new(big.Int).Set(start)
+    to := new(big.Int).Set(stop)
+    delta := new(big.Int).Set(step)
+    dec := from.Cmp(to) > 0
+    if dec {
+        delta.Neg(delta)
+    }
+
+    return func(yield func(*big.Int) bool) {
+        curr := new(big.Int).Set(from)
+        var buf *big.Int
+        if reuse {
+            buf = new(big.Int)
+        }
+        for {
+            if c := curr.Cmp(to); (!dec && c > 0) || (dec && c < 0) {
+                return
+            }
+            var v *big.Int
+            if reuse {
+                v = buf.Set(curr)
+            } else {
+                v = new(big.Int).Set(curr)
+            }
+            if !yield(v) {
+                return
+            }
+            curr.Add(curr, delta)
+        }
+    }
+}
+
+// Geometric returns an infinite iterator that yields the geometric progression start, start*ratio, start*ratio^2 and so on.
+// For integer types, the iteration stops before a value overflows, and for floating-point types, it stops before a value becomes infinite.
+// Combined with Take, it can be used as a backoff schedule.
+// For example:
+//
+//  goiter.Take(goiter.Geometric(100*time.Millisecond, 2), 4)   // will yield 100ms, 200ms, 400ms, 800ms
+func Geometric[T Number](start, ratio T) Iterator[T] {
+    return func(yield func(T) bool) {
+        one, two := T(1), T(2)
+        isFloat := one/two != 0
+        minusOne := T(0) - one
+        curr := start
+        for {
+            if !yield(curr) {
+                return
+            }
+            next := curr * ratio
+            if isFloat {
+                if math.IsInf(float64(next), 0) {
+                    return
+                }
+            } else if ratio != 0 && next/ratio != curr {
+                return
+            } else if ratio == minusOne && curr != 0 && next == curr {
+                // the minimum value of a signed type multiplied by -1 wraps to itself, and the division above can't detect it
+                return
+            }
+            curr = next
+        }
+    }
+}
+
+// Exponential returns an infinite iterator that yields the powers of base: base^0, base^1, base^2 and so on.
+// Each value is computed by math.Pow rather than accumulated, so there is no cumulative floating-point error.
+// The iteration stops before a value becomes infinite.
+func Exponential(base float64) Iterator[float64] {
+    return func(yield func(float64) bool) {
+        for i := 0; ; i++ {
+            v := math.Pow(base, float64(i))
+            if math.IsInf(v, 0) {
+                return
+            }
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
 // Counter returns an iterator that yields a sequence of integers incrementing by 1.
 func Counter(startFrom int) Iterator[int] {
     var next = startFrom
@@ -84,6 +217,39 @@ func Counter(startFrom int) Iterator[int] {
     })
 }
 
+// CounterStep is like Counter, but it increments by stepSize, or decrements if the optional descending parameter is true.
+// The same as RangeStep, stepSize must be positive regardless of the direction, otherwise nothing is yielded.
+// The iteration stops before the value overflows.
+// For example:
+//
+//  goiter.CounterStep(0, 2)            // will yield 0, 2, 4, 6, ...
+//  goiter.CounterStep(10, 1, true)     // will yield 10, 9, 8, 7, ...
+func CounterStep(start int, stepSize int, descending ...bool) Iterator[int] {
+    if stepSize <= 0 {
+        return Empty[int]()
+    }
+    desc := len(descending) > 0 && descending[0]
+    return func(yield func(int) bool) {
+        curr := start
+        for {
+            if !yield(curr) {
+                return
+            }
+            if desc {
+                if curr < math.MinInt+stepSize {
+                    return
+                }
+                curr -= stepSize
+            } else {
+                if curr > math.MaxInt-stepSize {
+                    return
+                }
+                curr += stepSize
+            }
+        }
+    }
+}
+
 // Sequence takes a generator function and returns an iterator that yields the values generated by the generator.
 // This is a general sequence generator function
 // For example, you can use it to generate the Fibonacci sequence like this:
@@ -136,16 +302,15 @@ func Sequence2[T1, T2 any](generator func() (T1, T2, bool)) Iterator2[T1, T2] {
 // So if the input iterator yields "a" "b" "c", then goiter.Reverse(iterator) will yield "c" "b" "a".
 //
 // be careful, if this function is used on iterators that has massive amount of data, it might consume a lot of memory.
-func Reverse[TIter SeqX[T], T any](iterator TIter) Iterator[T] {
+// The memory can be limited by WithMemoryBudget, optionally with WithSpill, see BufferOption.
+func Reverse[TIter SeqX[T], T any](iterator TIter, opts ...BufferOption[T]) Iterator[T] {
+    if budget := newBufferBudget[T](opts); budget != nil {
+        return Iterator[T](reverseWithin(iter.Seq[T](iterator), budget))
+    }
+
     return func(yield func(T) bool) {
         var buffer []T
-        next, stop := iter.Pull(iter.Seq[T](iterator))
-        defer stop()
-        for {
-            v, ok := next()
-            if !ok {
-                break
-            }
+        for v := range iterator {
             buffer = append(buffer, v)
         }
         for i := len(buffer) - 1; i >= 0; i-- {
@@ -156,18 +321,45 @@ func Reverse[TIter SeqX[T], T any](iterator TIter) Iterator[T] {
     }
 }
 
+// reverseWithin is Reverse with a memory budget, the spilled runs are read back one at a time from the last one.
+func reverseWithin[T any](iterator iter.Seq[T], budget *bufferBudget[T]) iter.Seq[T] {
+    return func(yield func(T) bool) {
+        budget.start()
+        runs, buffer, err := budget.collect(iterator, nil)
+        defer removeFiles(runs)
+        if err != nil {
+            budget.fail(err)
+            return
+        }
+
+        for i := len(runs); i >= 0; i-- {
+            if i < len(runs) {
+                buffer, err = readRun(runs[i], budget.codec)
+                if err != nil {
+                    budget.fail(err)
+                    return
+                }
+            }
+            for j := len(buffer) - 1; j >= 0; j-- {
+                if !yield(buffer[j]) {
+                    return
+                }
+            }
+        }
+    }
+}
+
 // Reverse2 is the iter.Seq2 version of Reverse function.
 // be careful, if this function is used on iterators that has massive amount of data, it might consume a lot of memory.
-func Reverse2[TIter Seq2X[T1, T2], T1, T2 any](iterator TIter) Iterator2[T1, T2] {
+// The memory can be limited by the options of element type *Combined[T1, T2], see BufferOption.
+func Reverse2[TIter Seq2X[T1, T2], T1, T2 any](iterator TIter, opts ...BufferOption[*Combined[T1, T2]]) Iterator2[T1, T2] {
+    if budget := newBufferBudget[*Combined[T1, T2]](opts); budget != nil {
+        return uncombineSeq(reverseWithin(combineSeq(iter.Seq2[T1, T2](iterator)), budget))
+    }
+
     return func(yield func(T1, T2) bool) {
         var buffer []*Combined[T1, T2]
-        next, stop := iter.Pull2(iter.Seq2[T1, T2](iterator))
-        defer stop()
-        for {
-            v1, v2, ok := next()
-            if !ok {
-                break
-            }
+        for v1, v2 := range iterator {
             buffer = append(buffer, &Combined[T1, T2]{V1: v1, V2: v2})
         }
         for i := len(buffer) - 1; i >= 0; i-- {
@@ -220,3 +412,166 @@ func countBits[T TInt](v T) int {
     }
     return 64
 }
+
+// Combinations returns an iterator that yields all k-element combinations of the input slice in lexicographic order of their indices.
+// The combinations are generated lazily, so no more than one combination is held in memory at a time.
+// For example:
+//
+//  goiter.Combinations([]int{1, 2, 3, 4}, 2) // will yield [1 2] [1 3] [1 4] [2 3] [2 4] [3 4]
+//
+// If k is 0, it yields a single empty slice. If k is negative or greater than the length of the slice, it yields nothing.
+// By default, each yielded slice is newly allocated, so it is safe to keep it after the iteration moves on.
+// If the optional reuseBuffer parameter is true, the same slice is reused for every combination to avoid allocations,
+// in this case you should copy the yielded slice if you need to keep it.
+func Combinations[S ~[]T, T any](items S, k int, reuseBuffer ...bool) Iterator[[]T] {
+    n := len(items)
+    if k < 0 || k > n {
+        return Empty[[]T]()
+    }
+    reuse := len(reuseBuffer) > 0 && reuseBuffer[0]
+
+    return func(yield func([]T) bool) {
+        indices := make([]int, k)
+        for i := range indices {
+            indices[i] = i
+        }
+        var buffer []T
+        if reuse {
+            buffer = make([]T, k)
+        }
+
+        for {
+            out := buffer
+            if !reuse {
+                out = make([]T, k)
+            }
+            for i, idx := range indices {
+                out[i] = items[idx]
+            }
+            if !yield(out) {
+                return
+            }
+
+            // find the rightmost index that can be incremented
+            i := k - 1
+            for i >= 0 && indices[i] == i+n-k {
+                i--
+            }
+            if i < 0 {
+                return
+            }
+            indices[i]++
+            for j := i + 1; j < k; j++ {
+                indices[j] = indices[j-1] + 1
+            }
+        }
+    }
+}
+
+// CalendarStep is the step of RangeTimeBy, in calendar units rather than a fixed duration.
+type CalendarStep struct {
+    Years  int
+    Months int
+    Days   int
+    // Location is the location in which the calendar arithmetic is done, if it is nil, the location of from is used.
+    Location *time.Location
+}
+
+// RangeTimeBy returns an iterator that yields the times from "from" to "to" inclusively, stepping by calendar units.
+// Each value is computed from "from" directly, keeping its wall clock time in the location of step, so day steps stay at the same local time across DST changes.
+// When stepping by months or years, the day of month is clamped to the last day of the target month, so monthly steps from Jan 31 yield Feb 28 (or 29), Mar 31 and so on.
+// If the step is not positive, or from is after to, it yields nothing.
+// For example:
+//
+//  from := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+//  to := time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)
+//  goiter.RangeTimeBy(from, to, goiter.CalendarStep{Months: 1})   // will yield 2024-01-31 2024-02-29 2024-03-31 2024-04-30
+func RangeTimeBy(from, to time.Time, step CalendarStep) Iterator[time.Time] {
+    if step.Years < 0 || step.Months < 0 || step.Days < 0 || step.Years+step.Months+step.Days == 0 || from.After(to) {
+        return Empty[time.Time]()
+    }
+    loc := step.Location
+    if loc == nil {
+        loc = from.Location()
+    }
+    start := from.In(loc)
+
+    return func(yield func(time.Time) bool) {
+        for i := 0; ; i++ {
+            curr := addCalendar(start, i*step.Years, i*step.Months, i*step.Days)
+            if curr.After(to) {
+                return
+            }
+            if !yield(curr) {
+                return
+            }
+        }
+    }
+}
+
+// addCalendar adds the calendar units to t, clamping the day of month to the last day of the target month.
+func addCalendar(t time.Time, years, months, days int) time.Time {
+    year, month, day := t.Date()
+    firstOfMonth := time.Date(year+years, month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+    lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+    if day > lastDay {
+        day = lastDay
+    }
+    hour, minute, sec := t.Clock()
+    return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day+days, hour, minute, sec, t.Nanosecond(), t.Location())
+}
+
+// DateOption configures RangeDate.
+type DateOption func(*dateConfig)
+
+type dateConfig struct {
+    location *time.Location
+    weekdays map[time.Weekday]bool
+}
+
+// WithDateLocation sets the location whose midnight the dates are truncated to, the default is the location of from.
+func WithDateLocation(loc *time.Location) DateOption {
+    return func(c *dateConfig) {
+        c.location = loc
+    }
+}
+
+// WithWeekdays makes RangeDate yield only the dates on the given weekdays.
+func WithWeekdays(weekdays ...time.Weekday) DateOption {
+    return func(c *dateConfig) {
+        c.weekdays = map[time.Weekday]bool{}
+        for _, w := range weekdays {
+            c.weekdays[w] = true
+        }
+    }
+}
+
+// RangeDate returns an iterator that yields the dates from the date of "from" to the date of "to" inclusively, stepping by whole days.
+// Each date is the midnight of the day in the location, so the clock parts of from and to are ignored.
+// If days is not positive, or from is after to, it yields nothing.
+// For example, to iterate the business days of a month:
+//
+//  from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+//  to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.Local)
+//  goiter.RangeDate(from, to, 1, goiter.WithWeekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday))
+func RangeDate(from, to time.Time, days int, opts ...DateOption) Iterator[time.Time] {
+    config := &dateConfig{location: from.Location()}
+    for _, opt := range opts {
+        opt(config)
+    }
+    if config.location == nil {
+        config.location = from.Location()
+    }
+    midnight := func(t time.Time) time.Time {
+        year, month, day := t.In(config.location).Date()
+        return time.Date(year, month, day, 0, 0, 0, 0, config.location)
+    }
+
+    dates := RangeTimeBy(midnight(from), midnight(to), CalendarStep{Days: days})
+    if config.weekdays == nil {
+        return dates
+    }
+    return Filter(dates, func(t time.Time) bool {
+        return config.weekdays[t.Weekday()]
+    })
+}
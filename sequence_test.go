@@ -3,8 +3,10 @@ package goiter
 import (
     "fmt"
     "math"
+    "math/big"
     "slices"
     "testing"
+    "time"
 )
 
 func TestRangeStep(t *testing.T) {
@@ -387,3 +389,278 @@ func TestIntMin(t *testing.T) {
         t.Fatalf("test int64 expect %d, got %d", int64(math.MinInt64), tMin(int64(0)))
     }
 }
+
+func TestCombinations(t *testing.T) {
+    actual := [][]int{}
+    for each := range Combinations([]int{1, 2, 3, 4}, 2) {
+        actual = append(actual, each)
+    }
+    expect := [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+    if !slices.EqualFunc(expect, actual, slices.Equal[[]int]) {
+        t.Fatalf("test Combinations failed, expect %v, got %v", expect, actual)
+    }
+
+    actual = [][]int{}
+    for each := range Combinations([]int{1, 2, 3}, 3, true) {
+        actual = append(actual, slices.Clone(each))
+    }
+    expect = [][]int{{1, 2, 3}}
+    if !slices.EqualFunc(expect, actual, slices.Equal[[]int]) {
+        t.Fatalf("test Combinations failed, expect %v, got %v", expect, actual)
+    }
+
+    var prev []int
+    for each := range Combinations([]int{1, 2, 3}, 2, true) {
+        if prev != nil && &prev[0] != &each[0] {
+            t.Fatalf("test Combinations failed, expect buffer to be reused")
+        }
+        prev = each
+    }
+
+    count := Combinations([]int{1, 2, 3}, 0).Count()
+    if count != 1 {
+        t.Fatalf("test Combinations failed, expect %d, got %d", 1, count)
+    }
+    count = Combinations([]int{1, 2, 3}, 4).Count()
+    if count != 0 {
+        t.Fatalf("test Combinations failed, expect %d, got %d", 0, count)
+    }
+    count = Combinations([]int{1, 2, 3}, -1).Count()
+    if count != 0 {
+        t.Fatalf("test Combinations failed, expect %d, got %d", 0, count)
+    }
+
+    for _ = range Combinations([]int{1, 2, 3}, 2) {
+        break
+    }
+}
+
+func TestRangeTimeBy(t *testing.T) {
+    format := func(it Iterator[time.Time]) []string {
+        return Transform(it, func(tm time.Time) string {
+            return tm.Format("2006-01-02 15:04 MST")
+        }).ToSlice()
+    }
+
+    from := time.Date(2024, 1, 31, 8, 30, 0, 0, time.UTC)
+    to := time.Date(2024, 4, 30, 8, 30, 0, 0, time.UTC)
+    actual := format(RangeTimeBy(from, to, CalendarStep{Months: 1}))
+    expect := []string{"2024-01-31 08:30 UTC", "2024-02-29 08:30 UTC", "2024-03-31 08:30 UTC", "2024-04-30 08:30 UTC"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = format(RangeTimeBy(time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), CalendarStep{Years: 2}))
+    expect = []string{"2020-02-29 00:00 UTC", "2022-02-28 00:00 UTC", "2024-02-29 00:00 UTC"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    loc, err := time.LoadLocation("America/New_York")
+    if err == nil {
+        from = time.Date(2024, 3, 9, 9, 0, 0, 0, loc).UTC()
+        to = from.Add(70 * time.Hour)
+        actual = format(RangeTimeBy(from, to, CalendarStep{Days: 1, Location: loc}))
+        expect = []string{"2024-03-09 09:00 EST", "2024-03-10 09:00 EDT", "2024-03-11 09:00 EDT"}
+        if !slices.Equal(expect, actual) {
+            t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+        }
+    }
+
+    if Count(RangeTimeBy(to, from, CalendarStep{Days: 1})) != 0 || Count(RangeTimeBy(from, to, CalendarStep{})) != 0 {
+        t.Fatal("expect nothing for the invalid ranges or steps")
+    }
+
+    for _ = range RangeTimeBy(from, to, CalendarStep{Days: 1}) {
+        break
+    }
+}
+
+func TestRangeDate(t *testing.T) {
+    format := func(it Iterator[time.Time]) []string {
+        return Transform(it, func(tm time.Time) string {
+            return tm.Format("2006-01-02 15:04")
+        }).ToSlice()
+    }
+
+    from := time.Date(2024, 2, 27, 18, 0, 0, 0, time.UTC)
+    to := time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC)
+    actual := format(RangeDate(from, to, 1))
+    expect := []string{"2024-02-27 00:00", "2024-02-28 00:00", "2024-02-29 00:00", "2024-03-01 00:00"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = format(RangeDate(from, to, 2))
+    expect = []string{"2024-02-27 00:00", "2024-02-29 00:00"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    weekdays := WithWeekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+    actual = format(RangeDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC), 1, weekdays))
+    expect = []string{"2024-03-01 00:00", "2024-03-04 00:00", "2024-03-05 00:00", "2024-03-06 00:00", "2024-03-07 00:00", "2024-03-08 00:00", "2024-03-11 00:00", "2024-03-12 00:00"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    tokyo := time.FixedZone("JST", 9*3600)
+    actual = format(RangeDate(from, from, 1, WithDateLocation(tokyo)))
+    expect = []string{"2024-02-28 00:00"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    if Count(RangeDate(from, to, 0)) != 0 || Count(RangeDate(to, from, 1)) != 0 {
+        t.Fatal("expect nothing for the invalid ranges or steps")
+    }
+}
+
+func TestRangeFloat(t *testing.T) {
+    actual := RangeFloat(0, 1, 5).ToSlice()
+    expect := []float64{0, 0.25, 0.5, 0.75, 1}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = RangeFloat(0, 1, 11).ToSlice()
+    if len(actual) != 11 || actual[3] != 0.30000000000000004 || actual[10] != 1 {
+        t.Fatal(fmt.Sprintf("expect values computed without accumulation, actual: %v", actual))
+    }
+
+    actual = RangeFloat(1, -1, 3).ToSlice()
+    expect = []float64{1, 0, -1}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = RangeFloat(2, 5, 1).ToSlice()
+    expect = []float64{2}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    if Count(RangeFloat(0, 1, 0)) != 0 {
+        t.Fatal("expect nothing for a non-positive n")
+    }
+
+    for _ = range RangeFloat(0, 1, 5) {
+        break
+    }
+}
+
+func TestRangeBig(t *testing.T) {
+    toStrings := func(it Iterator[*big.Int]) []string {
+        return Transform(it, func(v *big.Int) string {
+            return v.String()
+        }).ToSlice()
+    }
+
+    start, _ := new(big.Int).SetString("18446744073709551615", 10)
+    stop := new(big.Int).Add(start, big.NewInt(2))
+    actual := toStrings(RangeBig(start, stop, big.NewInt(1)))
+    expect := []string{"18446744073709551615", "18446744073709551616", "18446744073709551617"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = toStrings(RangeBig(big.NewInt(10), big.NewInt(1), big.NewInt(4)))
+    expect = []string{"10", "6", "2"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    values := []*big.Int{}
+    for v := range RangeBig(big.NewInt(1), big.NewInt(3), big.NewInt(1), true) {
+        values = append(values, v)
+    }
+    if len(values) != 3 || values[0] != values[2] || values[2].Int64() != 3 {
+        t.Fatal(fmt.Sprintf("expect the buffer to be reused, actual: %v", values))
+    }
+
+    if Count(RangeBig(big.NewInt(1), big.NewInt(3), big.NewInt(0))) != 0 || Count(RangeBig(nil, big.NewInt(3), big.NewInt(1))) != 0 {
+        t.Fatal("expect nothing for the invalid arguments")
+    }
+
+    for _ = range RangeBig(big.NewInt(1), big.NewInt(3), big.NewInt(1)) {
+        break
+    }
+}
+
+func TestGeometric(t *testing.T) {
+    actual := Take(Geometric(100*time.Millisecond, 2), 4).ToSlice()
+    expect := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actualInt8 := Geometric(int8(1), int8(-3)).ToSlice()
+    expectInt8 := []int8{1, -3, 9, -27, 81}
+    if !slices.Equal(expectInt8, actualInt8) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expectInt8, actualInt8))
+    }
+
+    actualInt64 := Geometric(int64(math.MinInt64), -1).ToSlice()
+    if !slices.Equal([]int64{math.MinInt64}, actualInt64) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int64{math.MinInt64}, actualInt64))
+    }
+    actualInt8 = Take(Geometric(int8(-5), -1), 3).ToSlice()
+    if !slices.Equal([]int8{-5, 5, -5}, actualInt8) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int8{-5, 5, -5}, actualInt8))
+    }
+    if n := Count(Take(Geometric(uint8(1), math.MaxUint8), 5)); n != 2 {
+        t.Fatal(fmt.Sprintf("expect 2 values before uint8 overflows, actual: %d", n))
+    }
+
+    actualFloat := Take(Geometric(1.0, 0.5), 3).ToSlice()
+    expectFloat := []float64{1, 0.5, 0.25}
+    if !slices.Equal(expectFloat, actualFloat) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expectFloat, actualFloat))
+    }
+
+    if n := Count(Geometric(float32(1), 10)); n != 39 {
+        t.Fatal(fmt.Sprintf("expect 39 values before float32 overflows, actual: %d", n))
+    }
+}
+
+func TestExponential(t *testing.T) {
+    actual := Take(Exponential(10), 4).ToSlice()
+    expect := []float64{1, 10, 100, 1000}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    if n := Count(Exponential(2)); n != 1024 {
+        t.Fatal(fmt.Sprintf("expect 1024 values before overflowing, actual: %d", n))
+    }
+}
+
+func TestCounterStep(t *testing.T) {
+    actual := Take(CounterStep(0, 2), 4).ToSlice()
+    expect := []int{0, 2, 4, 6}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = Take(CounterStep(1, 3, true), 4).ToSlice()
+    expect = []int{1, -2, -5, -8}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = CounterStep(math.MaxInt-3, 2).ToSlice()
+    expect = []int{math.MaxInt - 3, math.MaxInt - 1}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = CounterStep(math.MinInt+1, 1, true).ToSlice()
+    expect = []int{math.MinInt + 1, math.MinInt}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    if Count(CounterStep(0, 0)) != 0 {
+        t.Fatal("expect nothing for a non-positive step")
+    }
+}
@@ -0,0 +1,195 @@
+package goiter
+
+import (
+    "fmt"
+    "io"
+    "strings"
+    "text/tabwriter"
+)
+
+// Sink is a destination that the values of an iterator can be written to transactionally, such as a file written to a temporary path
+// and renamed on success, or a database transaction.
+type Sink[T any] interface {
+    // Write writes a value to the sink.
+    Write(T) error
+    // Flush commits all values written so far.
+    Flush() error
+    // Abort discards all values written so far.
+    Abort()
+}
+
+// Drive writes all values provided by the input iterator to the sink.
+// If all values are written successfully, the sink is flushed and the error returned by Flush is returned.
+// If Write or Flush fails, the iteration stops, the sink is aborted and the error is returned.
+func Drive[TIter SeqX[T], T any](iterator TIter, sink Sink[T]) error {
+    for v := range iterator {
+        if err := sink.Write(v); err != nil {
+            sink.Abort()
+            return err
+        }
+    }
+    if err := sink.Flush(); err != nil {
+        sink.Abort()
+        return err
+    }
+    return nil
+}
+
+// ForEach calls f for each value provided by the input iterator, the iteration stops as soon as f returns false.
+// For example:
+//
+//  goiter.ForEach(goiter.Counter(1), func(v int) bool {
+//      fmt.Println(v)
+//      return v < 3
+//  })  // prints 1 2 3
+func ForEach[TIter SeqX[T], T any](iterator TIter, f func(T) bool) {
+    for v := range iterator {
+        if !f(v) {
+            return
+        }
+    }
+}
+
+// ForEach2 is the iter.Seq2 version of ForEach function.
+func ForEach2[TIter Seq2X[T1, T2], T1, T2 any](iterator TIter, f func(T1, T2) bool) {
+    for v1, v2 := range iterator {
+        if !f(v1, v2) {
+            return
+        }
+    }
+}
+
+// JoinString concatenates the values provided by the input iterator into a single string, with sep placed between them.
+// Each value is converted to a string by format, if format is nil, fmt.Sprint is used.
+// The result is built in a single pass without collecting the values first.
+// For example:
+//
+//  goiter.JoinString(goiter.Range(1, 3), ", ", strconv.Itoa)    // "1, 2, 3"
+func JoinString[TIter SeqX[T], T any](iterator TIter, sep string, format func(T) string) string {
+    if format == nil {
+        format = func(v T) string {
+            return fmt.Sprint(v)
+        }
+    }
+    var sb strings.Builder
+    first := true
+    for v := range iterator {
+        if !first {
+            sb.WriteString(sep)
+        }
+        first = false
+        sb.WriteString(format(v))
+    }
+    return sb.String()
+}
+
+// WriteTo writes each value provided by the input iterator to w, formatted by format, and returns the total number of bytes written.
+// It stops consuming the input iterator on the first write error and returns it.
+// For example:
+//
+//  goiter.WriteTo(goiter.Range(1, 3), os.Stdout, func(v int) []byte {
+//      return []byte(strconv.Itoa(v) + "\n")
+//  })
+func WriteTo[TIter SeqX[T], T any](iterator TIter, w io.Writer, format func(T) []byte) (int64, error) {
+    var total int64
+    for v := range iterator {
+        n, err := w.Write(format(v))
+        total += int64(n)
+        if err != nil {
+            return total, err
+        }
+    }
+    return total, nil
+}
+
+// TableOptions configures WriteTable.
+type TableOptions struct {
+    // Markdown makes WriteTable write a markdown table instead of an aligned text table.
+    Markdown bool
+    // Padding is the number of spaces between columns of an aligned text table, the default is 2.
+    Padding int
+}
+
+// WriteTable writes the headers and the rows provided by the input iterator to w as a table.
+// By default, it writes an aligned text table, in which case the rows are buffered until the end to compute the column widths.
+// If opts.Markdown is true, it writes a markdown table, and each row is written as soon as it is yielded.
+// In a text table, tabs, newlines, carriage returns, vertical tabs and form feeds in the cells are written as \t, \n, \r, \v and \f,
+// so that a cell never breaks the alignment or spans multiple lines.
+// A nil opts is the same as the zero value of TableOptions. It stops consuming the rows on the first write error and returns it.
+// For example:
+//
+//  goiter.WriteTable(os.Stdout, []string{"NAME", "AGE"}, rows, nil)
+//  // NAME   AGE
+//  // alice  20
+//  // bob    3
+func WriteTable[TIter SeqX[[]string]](w io.Writer, headers []string, rows TIter, opts *TableOptions) error {
+    if opts == nil {
+        opts = &TableOptions{}
+    }
+    if opts.Markdown {
+        return writeMarkdownTable(w, headers, rows)
+    }
+
+    padding := opts.Padding
+    if padding <= 0 {
+        padding = 2
+    }
+    tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+    escaper := strings.NewReplacer("\t", `\t`, "\n", `\n`, "\r", `\r`, "\v", `\v`, "\f", `\f`)
+    writeRow := func(row []string) error {
+        var sb strings.Builder
+        for i, cell := range row {
+            if i > 0 {
+                sb.WriteString("\t")
+            }
+            sb.WriteString(escaper.Replace(cell))
+        }
+        sb.WriteString("\n")
+        _, err := io.WriteString(tw, sb.String())
+        return err
+    }
+    if len(headers) > 0 {
+        if err := writeRow(headers); err != nil {
+            return err
+        }
+    }
+    for row := range rows {
+        if err := writeRow(row); err != nil {
+            return err
+        }
+    }
+    return tw.Flush()
+}
+
+func writeMarkdownTable[TIter SeqX[[]string]](w io.Writer, headers []string, rows TIter) error {
+    escaper := strings.NewReplacer("|", "\\|", "\n", " ")
+    writeRow := func(row []string) error {
+        var sb strings.Builder
+        sb.WriteString("|")
+        for _, cell := range row {
+            sb.WriteString(" ")
+            sb.WriteString(escaper.Replace(cell))
+            sb.WriteString(" |")
+        }
+        sb.WriteString("\n")
+        _, err := io.WriteString(w, sb.String())
+        return err
+    }
+
+    if err := writeRow(headers); err != nil {
+        return err
+    }
+    separator := make([]string, len(headers))
+    for i := range separator {
+        separator[i] = "---"
+    }
+    if err := writeRow(separator); err != nil {
+        return err
+    }
+    for row := range rows {
+        if err := writeRow(row); err != nil {
+            return err
+        }
+    }
+    return nil
+}
@@ -0,0 +1,191 @@
+package goiter
+
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "slices"
+    "strconv"
+    "testing"
+)
+
+type testSink struct {
+    pending   []int
+    committed []int
+    aborted   bool
+    failOn    int
+    flushErr  error
+}
+
+func (s *testSink) Write(v int) error {
+    if v == s.failOn {
+        return fmt.Errorf("cannot write %d", v)
+    }
+    s.pending = append(s.pending, v)
+    return nil
+}
+
+func (s *testSink) Flush() error {
+    if s.flushErr != nil {
+        return s.flushErr
+    }
+    s.committed = append(s.committed, s.pending...)
+    s.pending = nil
+    return nil
+}
+
+func (s *testSink) Abort() {
+    s.pending = nil
+    s.aborted = true
+}
+
+func TestDrive(t *testing.T) {
+    // case 1: success
+    sink := &testSink{failOn: -1}
+    if err := Drive(Range(1, 3), sink); err != nil {
+        t.Fatal(fmt.Sprintf("unexpected error: %v", err))
+    }
+    if !slices.Equal([]int{1, 2, 3}, sink.committed) || sink.aborted {
+        t.Fatal(fmt.Sprintf("unexpected sink state: %+v", sink))
+    }
+
+    // case 2: write error aborts and stops consuming
+    consumed := 0
+    iterator := Transform(Range(1, 5), func(v int) int {
+        consumed++
+        return v
+    })
+    sink = &testSink{failOn: 3}
+    if err := Drive(iterator, sink); err == nil || err.Error() != "cannot write 3" {
+        t.Fatal(fmt.Sprintf("unexpected error: %v", err))
+    }
+    if len(sink.committed) != 0 || !sink.aborted || consumed != 3 {
+        t.Fatal(fmt.Sprintf("unexpected sink state: %+v, consumed: %d", sink, consumed))
+    }
+
+    // case 3: flush error aborts
+    flushErr := errors.New("flush failed")
+    sink = &testSink{failOn: -1, flushErr: flushErr}
+    if err := Drive(Range(1, 3), sink); !errors.Is(err, flushErr) || !sink.aborted {
+        t.Fatal(fmt.Sprintf("unexpected result: %v %+v", err, sink))
+    }
+}
+
+func TestWriteTable(t *testing.T) {
+    rows := [][]string{{"alice", "20"}, {"bob", "3"}}
+
+    buf := &bytes.Buffer{}
+    if err := WriteTable(buf, []string{"NAME", "AGE"}, SliceElems(rows), nil); err != nil {
+        t.Fatal(fmt.Sprintf("unexpected error: %v", err))
+    }
+    expect := "NAME   AGE\nalice  20\nbob    3\n"
+    if buf.String() != expect {
+        t.Fatal(fmt.Sprintf("expect: %q, actual: %q", expect, buf.String()))
+    }
+
+    buf.Reset()
+    if err := WriteTable(buf, []string{"NAME", "AGE"}, SliceElems(rows), &TableOptions{Padding: 1}); err != nil {
+        t.Fatal(fmt.Sprintf("unexpected error: %v", err))
+    }
+    expect = "NAME  AGE\nalice 20\nbob   3\n"
+    if buf.String() != expect {
+        t.Fatal(fmt.Sprintf("expect: %q, actual: %q", expect, buf.String()))
+    }
+
+    // control characters in cells don't break the table
+    buf.Reset()
+    if err := WriteTable(buf, []string{"NAME", "NOTE"}, SliceElems([][]string{{"a\tb", "x\ny"}, {"c", "z"}}), nil); err != nil {
+        t.Fatal(fmt.Sprintf("unexpected error: %v", err))
+    }
+    expect = "NAME  NOTE\na\\tb  x\\ny\nc     z\n"
+    if buf.String() != expect {
+        t.Fatal(fmt.Sprintf("expect: %q, actual: %q", expect, buf.String()))
+    }
+
+    buf.Reset()
+    rows = append(rows, []string{"a|b", "1"})
+    if err := WriteTable(buf, []string{"NAME", "AGE"}, SliceElems(rows), &TableOptions{Markdown: true}); err != nil {
+        t.Fatal(fmt.Sprintf("unexpected error: %v", err))
+    }
+    expect = "| NAME | AGE |\n| --- | --- |\n| alice | 20 |\n| bob | 3 |\n| a\\|b | 1 |\n"
+    if buf.String() != expect {
+        t.Fatal(fmt.Sprintf("expect: %q, actual: %q", expect, buf.String()))
+    }
+}
+
+func TestForEach(t *testing.T) {
+    actual := []int{}
+    Counter(1).ForEach(func(v int) bool {
+        actual = append(actual, v)
+        return v < 3
+    })
+    expect := []int{1, 2, 3}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestForEach2(t *testing.T) {
+    actual := []string{}
+    Slice([]string{"a", "b", "c"}).ForEach(func(idx int, v string) bool {
+        actual = append(actual, v)
+        return idx < 1
+    })
+    expect := []string{"a", "b"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestJoinString(t *testing.T) {
+    actual := JoinString(Range(1, 3), ", ", strconv.Itoa)
+    expect := "1, 2, 3"
+    if actual != expect {
+        t.Fatal(fmt.Sprintf("expect: %q, actual: %q", expect, actual))
+    }
+
+    actual = JoinString(Items(1.5, 2.5), "|", nil)
+    expect = "1.5|2.5"
+    if actual != expect {
+        t.Fatal(fmt.Sprintf("expect: %q, actual: %q", expect, actual))
+    }
+
+    actual = JoinString(Empty[int](), ",", strconv.Itoa)
+    if actual != "" {
+        t.Fatal(fmt.Sprintf("expect empty string, actual: %q", actual))
+    }
+}
+
+type failingWriter struct {
+    limit int
+    buf   bytes.Buffer
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+    if w.buf.Len()+len(p) > w.limit {
+        return 0, errors.New("writer is full")
+    }
+    return w.buf.Write(p)
+}
+
+func TestWriteTo(t *testing.T) {
+    format := func(v int) []byte {
+        return []byte(strconv.Itoa(v) + ",")
+    }
+    buf := &bytes.Buffer{}
+    n, err := Range(1, 3).WriteTo(buf, format)
+    if err != nil || n != 6 || buf.String() != "1,2,3," {
+        t.Fatal(fmt.Sprintf("expect 6 bytes \"1,2,3,\", actual: %d %q %v", n, buf.String(), err))
+    }
+
+    consumed := 0
+    source := Transform(Counter(1), func(v int) int {
+        consumed++
+        return v
+    })
+    w := &failingWriter{limit: 5}
+    n, err = WriteTo(source, w, format)
+    if err == nil || n != 4 || consumed != 3 {
+        t.Fatal(fmt.Sprintf("expect an error after 4 bytes and 3 values, actual: %d bytes, %d values, %v", n, consumed, err))
+    }
+}
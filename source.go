@@ -1,10 +1,20 @@
 package goiter
 
+import (
+    "cmp"
+    "container/list"
+    "container/ring"
+    "reflect"
+    "slices"
+    "sync"
+)
+
 // SourceFunc delegates data retrieval from elsewhere.
 type SourceFunc[T any] func() T
 
 // Slice returns an iterator that allows you to traverse a slice in a forward or reverse direction.
 // So this function combines the functionalities of slices.Values and slices.Backward.
+// A nil slice is treated as an empty slice, so it yields nothing.
 func Slice[S ~[]T, T any](s S, backward ...bool) Iterator2[int, T] {
     return SliceSource(func() S { return s }, backward...)
 }
@@ -20,8 +30,12 @@ func SliceElems[S ~[]T, T any](s S, backward ...bool) Iterator[T] {
 // However, there might come a time when the structure replaces the original slice with a new one.
 // When the already exposed iterator is traversed again, we hope it traverses the new slice instead of the old one.
 // Therefore, by providing a SourceFunc, the moment of obtaining the slice is delayed until the iterator is traversed.
+// A nil SourceFunc is treated as a source of an empty slice. This applies to all *Source functions.
 func SliceSource[S ~[]T, T any](source SourceFunc[S], backward ...bool) Iterator2[int, T] {
     return func(yield func(int, T) bool) {
+        if source == nil {
+            return
+        }
         s := source()
         if len(backward) == 0 || !backward[0] {
             for idx, elem := range s {
@@ -43,6 +57,9 @@ func SliceSource[S ~[]T, T any](source SourceFunc[S], backward ...bool) Iterator
 // see comments of SliceSource function for more details.
 func SliceSourceElems[S ~[]T, T any](source SourceFunc[S], backward ...bool) Iterator[T] {
     return func(yield func(T) bool) {
+        if source == nil {
+            return
+        }
         s := source()
         if len(backward) == 0 || !backward[0] {
             for _, elem := range s {
@@ -62,6 +79,7 @@ func SliceSourceElems[S ~[]T, T any](source SourceFunc[S], backward ...bool) Ite
 
 // Map returns an iterator that allows you to traverse a map.
 // This function is basically equivalent to the maps.All function.
+// A nil map is treated as an empty map, so it yields nothing.
 func Map[K comparable, V any](m map[K]V) Iterator2[K, V] {
     return MapSource(func() map[K]V { return m })
 }
@@ -82,6 +100,9 @@ func MapVals[K comparable, V any](m map[K]V) Iterator[V] {
 // See comments of SliceSource function for more details.
 func MapSource[K comparable, V any](source SourceFunc[map[K]V]) Iterator2[K, V] {
     return func(yield func(K, V) bool) {
+        if source == nil {
+            return
+        }
         m := source()
         for key, val := range m {
             if !yield(key, val) {
@@ -95,6 +116,9 @@ func MapSource[K comparable, V any](source SourceFunc[map[K]V]) Iterator2[K, V]
 // See comments of SliceSource function for more details.
 func MapSourceKeys[K comparable, V any](source SourceFunc[map[K]V]) Iterator[K] {
     return func(yield func(K) bool) {
+        if source == nil {
+            return
+        }
         m := source()
         for key := range m {
             if !yield(key) {
@@ -108,6 +132,9 @@ func MapSourceKeys[K comparable, V any](source SourceFunc[map[K]V]) Iterator[K]
 // See comments of SliceSource function for more details.
 func MapSourceVals[K comparable, V any](source SourceFunc[map[K]V]) Iterator[V] {
     return func(yield func(V) bool) {
+        if source == nil {
+            return
+        }
         m := source()
         for _, val := range m {
             if !yield(val) {
@@ -117,11 +144,64 @@ func MapSourceVals[K comparable, V any](source SourceFunc[map[K]V]) Iterator[V]
     }
 }
 
+// MapSorted is like Map function, but it yields the key-value pairs in ascending order of the keys,
+// or in descending order if the second parameter is true.
+// Only the keys are collected and sorted, the values are looked up from the map as the iteration goes,
+// so it is cheaper than Order2V1(Map(m)). The keys deleted from the map during the iteration are skipped.
+// For example:
+//
+//  goiter.MapSorted(map[string]int{"bob": 3, "eve": 2, "alice": 1})   // yields ("alice", 1) ("bob", 3) ("eve", 2)
+func MapSorted[K cmp.Ordered, V any](m map[K]V, desc ...bool) Iterator2[K, V] {
+    return func(yield func(K, V) bool) {
+        keys := make([]K, 0, len(m))
+        for key := range m {
+            keys = append(keys, key)
+        }
+        slices.Sort(keys)
+        if len(desc) > 0 && desc[0] {
+            slices.Reverse(keys)
+        }
+        for _, key := range keys {
+            val, ok := m[key]
+            if !ok {
+                continue
+            }
+            if !yield(key, val) {
+                return
+            }
+        }
+    }
+}
+
+// MapSliceElems yields a (key, element) pair for each element of each slice in the map, it is the inverse of ToMultiMap function.
+// The keys are visited in arbitrary order, and the elements of each slice are yielded in order.
+// For example:
+//
+//  goiter.MapSliceElems(map[string][]int{"a": {1, 2}, "b": {3}})   // yields ("a", 1) ("a", 2) ("b", 3), or ("b", 3) ("a", 1) ("a", 2)
+func MapSliceElems[K comparable, V any](m map[K][]V) Iterator2[K, V] {
+    return func(yield func(K, V) bool) {
+        for key, s := range m {
+            for _, elem := range s {
+                if !yield(key, elem) {
+                    return
+                }
+            }
+        }
+    }
+}
+
 // SeqSource serves similar purposes as SliceSource, the difference is that the SourceFunc returns an iter.Seq-like iterator.
+// If the SourceFunc returns a nil iterator, it yields nothing.
 // See comments of SliceSource function for more details.
 func SeqSource[TIter SeqX[T], T any](source SourceFunc[TIter]) Iterator[T] {
     return func(yield func(T) bool) {
+        if source == nil {
+            return
+        }
         seq := source()
+        if seq == nil {
+            return
+        }
         for v := range seq {
             if !yield(v) {
                 return
@@ -134,7 +214,13 @@ func SeqSource[TIter SeqX[T], T any](source SourceFunc[TIter]) Iterator[T] {
 // See comments of SliceSource function for more details.
 func Seq2Source[TIter Seq2X[T1, T2], T1, T2 any](source SourceFunc[TIter]) Iterator2[T1, T2] {
     return func(yield func(T1, T2) bool) {
+        if source == nil {
+            return
+        }
         seq := source()
+        if seq == nil {
+            return
+        }
         for v1, v2 := range seq {
             if !yield(v1, v2) {
                 return
@@ -149,6 +235,17 @@ func Items[T any](t ...T) Iterator[T] {
     return SliceElems(t)
 }
 
+// FromPtr returns an iterator that yields the value pointed to by p, or yields nothing if p is nil.
+// It is useful for optional values, for example, an optional field of a struct can be concatenated with other iterators without a nil check.
+func FromPtr[T any](p *T) Iterator[T] {
+    return func(yield func(T) bool) {
+        if p == nil {
+            return
+        }
+        yield(*p)
+    }
+}
+
 // Empty returns an empty iterator.
 func Empty[T any]() Iterator[T] {
     return func(yield func(T) bool) {
@@ -162,3 +259,90 @@ func Empty2[T1 any, T2 any]() Iterator2[T1, T2] {
         return
     }
 }
+
+// SyncMap returns an iterator that traverses a sync.Map, it is based on the Range method of sync.Map,
+// so it has the same consistency guarantees, and the map can be modified concurrently during the iteration.
+// The entries whose key or value is not of type K or V are skipped, a nil value is yielded as the zero value of V if V is nilable.
+// A nil map is treated as an empty map, so it yields nothing.
+func SyncMap[K, V any](m *sync.Map) Iterator2[K, V] {
+    return func(yield func(K, V) bool) {
+        if m == nil {
+            return
+        }
+        m.Range(func(key, value any) bool {
+            k, ok := typedAs[K](key)
+            if !ok {
+                return true
+            }
+            v, ok := typedAs[V](value)
+            if !ok {
+                return true
+            }
+            return yield(k, v)
+        })
+    }
+}
+
+// typedAs is like a type assertion, but a nil value is converted to the zero value of T if T is nilable.
+func typedAs[T any](v any) (T, bool) {
+    if t, ok := v.(T); ok {
+        return t, true
+    }
+    var zero T
+    if v != nil {
+        return zero, false
+    }
+    switch reflect.TypeFor[T]().Kind() {
+    case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+        return zero, true
+    }
+    return zero, false
+}
+
+// ListElems returns an iterator that yields the values of the elements of a container/list, forward or backward.
+// The values that are not of type T are skipped, a nil value is yielded as the zero value of T if T is nilable.
+// The next element is determined before yielding the current one, so it is safe to remove the current element during the iteration.
+// A nil list is treated as an empty list, so it yields nothing.
+func ListElems[T any](l *list.List, backward ...bool) Iterator[T] {
+    return func(yield func(T) bool) {
+        if l == nil {
+            return
+        }
+        e, step := l.Front(), (*list.Element).Next
+        if len(backward) > 0 && backward[0] {
+            e, step = l.Back(), (*list.Element).Prev
+        }
+        for e != nil {
+            next := step(e)
+            if v, ok := typedAs[T](e.Value); ok {
+                if !yield(v) {
+                    return
+                }
+            }
+            e = next
+        }
+    }
+}
+
+// RingElems returns an iterator that yields the values of a container/ring, starting from r and going forward until it gets back to r.
+// The values are handled in the same way as ListElems function.
+// A nil ring yields nothing.
+func RingElems[T any](r *ring.Ring) Iterator[T] {
+    return func(yield func(T) bool) {
+        if r == nil {
+            return
+        }
+        p := r
+        for {
+            if v, ok := typedAs[T](p.Value); ok {
+                if !yield(v) {
+                    return
+                }
+            }
+            p = p.Next()
+            if p == r {
+                return
+            }
+        }
+    }
+}
@@ -1,9 +1,12 @@
 package goiter
 
 import (
+    "container/list"
+    "container/ring"
     "fmt"
     "iter"
     "slices"
+    "sync"
     "testing"
 )
 
@@ -176,6 +179,150 @@ func TestMapSourceKeys(t *testing.T) {
     }
 }
 
+func TestMapSorted(t *testing.T) {
+    m := map[string]int{"bob": 3, "eve": 2, "alice": 1}
+    keys, vals := MapSorted(m).ToSlices()
+    if !slices.Equal([]string{"alice", "bob", "eve"}, keys) || !slices.Equal([]int{1, 3, 2}, vals) {
+        t.Fatal(fmt.Sprintf("expect: [alice bob eve] [1 3 2], actual: %v %v", keys, vals))
+    }
+
+    keys, vals = MapSorted(m, true).ToSlices()
+    if !slices.Equal([]string{"eve", "bob", "alice"}, keys) || !slices.Equal([]int{2, 3, 1}, vals) {
+        t.Fatal(fmt.Sprintf("expect: [eve bob alice] [2 3 1], actual: %v %v", keys, vals))
+    }
+
+    keys = []string{}
+    for k, _ := range MapSorted(m) {
+        keys = append(keys, k)
+        if k == "alice" {
+            delete(m, "bob")
+        }
+    }
+    if !slices.Equal([]string{"alice", "eve"}, keys) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []string{"alice", "eve"}, keys))
+    }
+
+    if n := Count2(MapSorted[int, int](nil)); n != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", n))
+    }
+}
+
+func TestMapSliceElems(t *testing.T) {
+    grouped := map[string][]int{"a": {1, 2}, "b": {3}, "c": nil}
+    actual := ToMultiMap(MapSliceElems(grouped))
+    if len(actual) != 2 || !slices.Equal([]int{1, 2}, actual["a"]) || !slices.Equal([]int{3}, actual["b"]) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", grouped, actual))
+    }
+
+    count := 0
+    for _, _ = range MapSliceElems(grouped) {
+        count++
+        break
+    }
+    if count != 1 {
+        t.Fatal(fmt.Sprintf("expect: 1, actual: %d", count))
+    }
+
+    if n := Count2(MapSliceElems[string, int](nil)); n != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", n))
+    }
+}
+
+func TestSyncMap(t *testing.T) {
+    m := &sync.Map{}
+    m.Store("a", 1)
+    m.Store("b", 2)
+    m.Store("c", "not an int")
+    m.Store(3, 3)
+    actual := ToMap(SyncMap[string, int](m))
+    if len(actual) != 2 || actual["a"] != 1 || actual["b"] != 2 {
+        t.Fatal(fmt.Sprintf("expect: map[a:1 b:2], actual: %v", actual))
+    }
+
+    count := 0
+    for _, _ = range SyncMap[string, int](m) {
+        count++
+        break
+    }
+    if count != 1 {
+        t.Fatal(fmt.Sprintf("expect: 1, actual: %d", count))
+    }
+
+    n := &sync.Map{}
+    n.Store("nil", nil)
+    if c := Count2(SyncMap[string, *int](n)); c != 1 {
+        t.Fatal(fmt.Sprintf("expect: 1, actual: %d", c))
+    }
+    if c := Count2(SyncMap[string, int](n)); c != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", c))
+    }
+    if c := Count2(SyncMap[string, int](nil)); c != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", c))
+    }
+}
+
+func TestListElems(t *testing.T) {
+    l := list.New()
+    l.PushBack(1)
+    l.PushBack("skipped")
+    l.PushBack(2)
+    l.PushBack(3)
+
+    actual := ListElems[int](l).ToSlice()
+    if !slices.Equal([]int{1, 2, 3}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2, 3}, actual))
+    }
+    actual = ListElems[int](l, true).ToSlice()
+    if !slices.Equal([]int{3, 2, 1}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{3, 2, 1}, actual))
+    }
+    actual = ListElems[int](l).Take(1).ToSlice()
+    if !slices.Equal([]int{1}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1}, actual))
+    }
+
+    // remove the current element during the iteration
+    actual = []int{}
+    for v := range ListElems[int](l) {
+        actual = append(actual, v)
+        if v == 1 {
+            l.Remove(l.Front())
+        }
+    }
+    if !slices.Equal([]int{1, 2, 3}, actual) || l.Len() != 3 {
+        t.Fatal(fmt.Sprintf("expect: %v with 3 elements left, actual: %v with %d elements left", []int{1, 2, 3}, actual, l.Len()))
+    }
+
+    if n := Count(ListElems[int](nil)); n != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", n))
+    }
+}
+
+func TestRingElems(t *testing.T) {
+    r := ring.New(4)
+    for i := 1; i <= 4; i++ {
+        r.Value = i
+        r = r.Next()
+    }
+    r = r.Move(2)
+
+    actual := RingElems[int](r).ToSlice()
+    if !slices.Equal([]int{3, 4, 1, 2}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{3, 4, 1, 2}, actual))
+    }
+    actual = RingElems[int](r).Take(2).ToSlice()
+    if !slices.Equal([]int{3, 4}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{3, 4}, actual))
+    }
+
+    if n := Count(RingElems[int](ring.New(3))); n != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", n))
+    }
+    if n := Count(RingElems[int](nil)); n != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", n))
+    }
+}
+
 func TestSeqSource(t *testing.T) {
     itFunc := func(yield func(int) bool) {
         yield(1)
@@ -304,3 +451,45 @@ func TestEmpty2(t *testing.T) {
         t.Fatal(fmt.Sprintf("expect: 0, actual: %d", i))
     }
 }
+
+func TestNilSources(t *testing.T) {
+    if Slice([]int(nil)).Count() != 0 || SliceElems([]int(nil)).Count() != 0 {
+        t.Fatal("expect nil slice to yield nothing")
+    }
+    if Map(map[string]int(nil)).Count() != 0 || MapKeys(map[string]int(nil)).Count() != 0 || MapVals(map[string]int(nil)).Count() != 0 {
+        t.Fatal("expect nil map to yield nothing")
+    }
+    if SliceSource[[]int](nil).Count() != 0 || SliceSourceElems[[]int](nil).Count() != 0 {
+        t.Fatal("expect nil slice source to yield nothing")
+    }
+    if MapSource[string, int](nil).Count() != 0 || MapSourceKeys[string, int](nil).Count() != 0 || MapSourceVals[string, int](nil).Count() != 0 {
+        t.Fatal("expect nil map source to yield nothing")
+    }
+    if SeqSource[iter.Seq[int]](nil).Count() != 0 || SeqSource(func() iter.Seq[int] { return nil }).Count() != 0 {
+        t.Fatal("expect nil seq source to yield nothing")
+    }
+    if Seq2Source[iter.Seq2[int, int]](nil).Count() != 0 || Seq2Source(func() iter.Seq2[int, int] { return nil }).Count() != 0 {
+        t.Fatal("expect nil seq2 source to yield nothing")
+    }
+}
+
+func TestFromPtr(t *testing.T) {
+    v := 5
+    actual := []int{}
+    for each := range FromPtr(&v) {
+        actual = append(actual, each)
+    }
+    expect := []int{5}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []int{}
+    for each := range FromPtr[int](nil) {
+        actual = append(actual, each)
+    }
+    expect = []int{}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
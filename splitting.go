@@ -0,0 +1,272 @@
+package goiter
+
+import (
+    "iter"
+    "runtime"
+    "strings"
+    "sync"
+)
+
+// SplitWhen returns an iterator that splits the values of the input iterator into segments at the boundary values,
+// a value is a boundary if isBoundary returns true for it.
+// By default, the boundary values are dropped. If the optional keepBoundary parameter is true, each boundary value is kept at the end of the segment it terminates.
+// Empty segments are not yielded.
+// For example:
+//
+//  iterator := goiter.Items("a", "b", "", "c", "", "", "d")
+//  goiter.SplitWhen(iterator, func(s string) bool { return s == "" })        // will yield ["a" "b"] ["c"] ["d"]
+//  goiter.SplitWhen(iterator, func(s string) bool { return s == "" }, true)  // will yield ["a" "b" ""] ["c" ""] [""] ["d"]
+func SplitWhen[TIter SeqX[T], T any](
+    iterator TIter,
+    isBoundary func(T) bool,
+    keepBoundary ...bool,
+) Iterator[[]T] {
+    keep := len(keepBoundary) > 0 && keepBoundary[0]
+    return func(yield func([]T) bool) {
+        var segment []T
+        for v := range iterator {
+            if !isBoundary(v) {
+                segment = append(segment, v)
+                continue
+            }
+            if keep {
+                segment = append(segment, v)
+            }
+            if len(segment) > 0 {
+                if !yield(segment) {
+                    return
+                }
+                segment = nil
+            }
+        }
+        if len(segment) > 0 {
+            yield(segment)
+        }
+    }
+}
+
+// BreakBefore returns an iterator that splits the values of the input iterator into chunks,
+// a new chunk is started before each value that satisfies the predicate.
+// It is useful for parsing multi-line records, where a marker line starts a new record.
+// For example:
+//
+//  iterator := goiter.Items("ERROR a", "  at x", "  at y", "INFO b")
+//  goiter.BreakBefore(iterator, func(s string) bool { return !strings.HasPrefix(s, " ") })
+//  // will yield ["ERROR a" "  at x" "  at y"] ["INFO b"]
+func BreakBefore[TIter SeqX[T], T any](
+    iterator TIter,
+    predicate func(T) bool,
+) Iterator[[]T] {
+    return func(yield func([]T) bool) {
+        var chunk []T
+        for v := range iterator {
+            if predicate(v) && len(chunk) > 0 {
+                if !yield(chunk) {
+                    return
+                }
+                chunk = nil
+            }
+            chunk = append(chunk, v)
+        }
+        if len(chunk) > 0 {
+            yield(chunk)
+        }
+    }
+}
+
+// BreakAfter is like BreakBefore, but a chunk is ended after each value that satisfies the predicate.
+// For example:
+//
+//  goiter.BreakAfter(goiter.Items("a", "b;", "c;", "d"), func(s string) bool { return strings.HasSuffix(s, ";") })
+//  // will yield ["a" "b;"] ["c;"] ["d"]
+func BreakAfter[TIter SeqX[T], T any](
+    iterator TIter,
+    predicate func(T) bool,
+) Iterator[[]T] {
+    return SplitWhen(iterator, predicate, true)
+}
+
+// AssembleRecords returns an iterator that joins continuation lines into full records, such as stack traces or folded MIME headers.
+// A new record starts at each line for which isStart returns true, and the following lines are appended to it, separated by "\n".
+// Lines before the first start line form a record of their own.
+// For example:
+//
+//  lines := goiter.Items("ERROR boom", "  at main.go:1", "INFO ok")
+//  goiter.AssembleRecords(lines, func(s string) bool { return !strings.HasPrefix(s, " ") })
+//  // will yield "ERROR boom\n  at main.go:1" "INFO ok"
+func AssembleRecords[TIter SeqX[string]](lines TIter, isStart func(string) bool) Iterator[string] {
+    return Transform(BreakBefore(lines, isStart), func(record []string) string {
+        return strings.Join(record, "\n")
+    })
+}
+
+// SplitAt splits the input iterator into two iterators, the first one yields the first n values, and the second one yields the rest.
+// Unlike combining Take and Skip, the input iterator is traversed only once, so it works correctly with one-shot iterators such as those created by Once.
+// The two iterators can be consumed in any order, if the second one is consumed first, the first n values are buffered for the first one.
+// Both iterators continue from where they left off when they are ranged over again, and they yield nothing after they are finished.
+// The input iterator is released when the second iterator is finished or broken out of, or when the input iterator is exhausted.
+// If only the first iterator is consumed, the input iterator is released once both iterators are garbage collected,
+// so range over the second iterator, or break out of it at once, to release the resources held by the input iterator without delay.
+// For example:
+//
+//  head, tail := goiter.SplitAt(goiter.Once(goiter.Items(1, 2, 3, 4, 5)), 2)
+//  // head will yield 1 2, tail will yield 3 4 5
+func SplitAt[TIter SeqX[T], T any](iterator TIter, n int) (Iterator[T], Iterator[T]) {
+    s := newSplitter(&splitter[T]{
+        seq: iter.Seq[T](iterator),
+        atBoundary: func(count int) bool {
+            return count >= n
+        },
+    })
+    return s.prefix(), s.rest()
+}
+
+// Span splits the input iterator into two iterators, the first one yields the longest prefix of values that satisfy the predicate,
+// and the second one yields the rest, starting from the first value that doesn't satisfy the predicate.
+// It has the same single-pass semantics as SplitAt, see comments of SplitAt function for more details.
+// For example:
+//
+//  head, tail := goiter.Span(goiter.Items(1, 2, 5, 1, 2), func(v int) bool { return v < 3 })
+//  // head will yield 1 2, tail will yield 5 1 2
+func Span[TIter SeqX[T], T any](iterator TIter, predicate func(T) bool) (Iterator[T], Iterator[T]) {
+    s := newSplitter(&splitter[T]{
+        seq:       iter.Seq[T](iterator),
+        predicate: predicate,
+    })
+    return s.prefix(), s.rest()
+}
+
+// splitter shares one pass over the input iterator between the prefix and the rest iterators.
+type splitter[T any] struct {
+    mu         sync.Mutex
+    seq        iter.Seq[T]
+    next       func() (T, bool)
+    stop       func()
+    atBoundary func(count int) bool
+    predicate  func(T) bool
+
+    count      int
+    prefixDone bool
+    exhausted  bool
+    // buffered holds the prefix values pulled by the rest iterator that haven't been yielded by the prefix iterator
+    buffered []T
+    // pending holds the first value of the rest
+    pending    T
+    hasPending bool
+}
+
+// newSplitter makes sure the input iterator is released when the splitter becomes unreachable,
+// otherwise the goroutine of iter.Pull is leaked if the rest iterator is never ranged over.
+// The pulling goroutine only references the input iterator, not the splitter, so the splitter can be collected while it is blocked.
+func newSplitter[T any](s *splitter[T]) *splitter[T] {
+    runtime.SetFinalizer(s, func(s *splitter[T]) {
+        if !s.exhausted && s.stop != nil {
+            // stop runs the deferred calls of the input iterator, which should not block the finalizer goroutine.
+            go s.stop()
+        }
+    })
+    return s
+}
+
+func (s *splitter[T]) prefix() Iterator[T] {
+    return func(yield func(T) bool) {
+        for {
+            v, ok := s.nextPrefix()
+            if !ok || !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+func (s *splitter[T]) rest() Iterator[T] {
+    return func(yield func(T) bool) {
+        for {
+            v, ok := s.nextRest()
+            if !ok {
+                return
+            }
+            if !yield(v) {
+                s.mu.Lock()
+                s.finish()
+                s.mu.Unlock()
+                return
+            }
+        }
+    }
+}
+
+func (s *splitter[T]) nextPrefix() (T, bool) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if len(s.buffered) > 0 {
+        v := s.buffered[0]
+        s.buffered = s.buffered[1:]
+        return v, true
+    }
+    return s.pullPrefix()
+}
+
+func (s *splitter[T]) nextRest() (T, bool) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for !s.prefixDone {
+        if v, ok := s.pullPrefix(); ok {
+            s.buffered = append(s.buffered, v)
+        }
+    }
+    if s.hasPending {
+        v := s.pending
+        var zero T
+        s.pending, s.hasPending = zero, false
+        return v, true
+    }
+    return s.pull()
+}
+
+func (s *splitter[T]) pullPrefix() (T, bool) {
+    var zero T
+    if s.prefixDone {
+        return zero, false
+    }
+    if s.atBoundary != nil && s.atBoundary(s.count) {
+        s.prefixDone = true
+        return zero, false
+    }
+    v, ok := s.pull()
+    if !ok {
+        s.prefixDone = true
+        return zero, false
+    }
+    if s.predicate != nil && !s.predicate(v) {
+        s.prefixDone = true
+        s.pending, s.hasPending = v, true
+        return zero, false
+    }
+    s.count++
+    return v, true
+}
+
+func (s *splitter[T]) pull() (T, bool) {
+    var zero T
+    if s.exhausted {
+        return zero, false
+    }
+    if s.next == nil {
+        s.next, s.stop = iter.Pull(s.seq)
+    }
+    v, ok := s.next()
+    if !ok {
+        s.finish()
+    }
+    return v, ok
+}
+
+func (s *splitter[T]) finish() {
+    s.exhausted = true
+    if s.stop != nil {
+        s.stop()
+    }
+}
@@ -0,0 +1,195 @@
+package goiter
+
+import (
+    "fmt"
+    "runtime"
+    "slices"
+    "testing"
+    "time"
+)
+
+func TestSplitWhen(t *testing.T) {
+    isBoundary := func(s string) bool { return s == "" }
+    input := []string{"a", "b", "", "c", "", "", "d"}
+
+    actual := [][]string{}
+    for each := range SplitWhen(SliceElems(input), isBoundary) {
+        actual = append(actual, each)
+    }
+    expect := [][]string{{"a", "b"}, {"c"}, {"d"}}
+    if !slices.EqualFunc(expect, actual, slices.Equal[[]string]) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = [][]string{}
+    for each := range SplitWhen(SliceElems(input), isBoundary, true) {
+        actual = append(actual, each)
+    }
+    expect = [][]string{{"a", "b", ""}, {"c", ""}, {""}, {"d"}}
+    if !slices.EqualFunc(expect, actual, slices.Equal[[]string]) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    if SplitWhen(Items("", ""), isBoundary).Count() != 0 {
+        t.Fatal("expect no segments")
+    }
+
+    for _ = range SplitWhen(SliceElems(input), isBoundary) {
+        break
+    }
+}
+
+func TestBreakBefore(t *testing.T) {
+    isStart := func(s string) bool { return s[0] != ' ' }
+    input := []string{"  orphan", "ERROR a", "  at x", "  at y", "INFO b", "WARN c"}
+
+    actual := [][]string{}
+    for each := range BreakBefore(SliceElems(input), isStart) {
+        actual = append(actual, each)
+    }
+    expect := [][]string{{"  orphan"}, {"ERROR a", "  at x", "  at y"}, {"INFO b"}, {"WARN c"}}
+    if !slices.EqualFunc(expect, actual, slices.Equal[[]string]) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _ = range BreakBefore(SliceElems(input), isStart) {
+        break
+    }
+}
+
+func TestBreakAfter(t *testing.T) {
+    isEnd := func(s string) bool { return s[len(s)-1] == ';' }
+    actual := [][]string{}
+    for each := range BreakAfter(Items("a", "b;", "c;", "d"), isEnd) {
+        actual = append(actual, each)
+    }
+    expect := [][]string{{"a", "b;"}, {"c;"}, {"d"}}
+    if !slices.EqualFunc(expect, actual, slices.Equal[[]string]) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestSplitAt(t *testing.T) {
+    collect := func(it Iterator[int]) []int {
+        result := []int{}
+        for v := range it {
+            result = append(result, v)
+        }
+        return result
+    }
+
+    // case 1: consume the head first
+    head, tail := SplitAt(Once(Items(1, 2, 3, 4, 5)), 2)
+    if actual := collect(head); !slices.Equal([]int{1, 2}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2}, actual))
+    }
+    if actual := collect(tail); !slices.Equal([]int{3, 4, 5}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{3, 4, 5}, actual))
+    }
+
+    // case 2: consume the tail first, the head is buffered
+    head, tail = SplitAt(Once(Items(1, 2, 3, 4, 5)), 2)
+    if actual := collect(tail); !slices.Equal([]int{3, 4, 5}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{3, 4, 5}, actual))
+    }
+    if actual := collect(head); !slices.Equal([]int{1, 2}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2}, actual))
+    }
+
+    // case 3: fewer values than n, and resuming after breaking out
+    head, tail = SplitAt(Once(Items(1, 2, 3)), 5)
+    for _ = range head {
+        break
+    }
+    if actual := collect(head); !slices.Equal([]int{2, 3}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{2, 3}, actual))
+    }
+    if actual := collect(tail); len(actual) != 0 {
+        t.Fatal(fmt.Sprintf("expect nothing, actual: %v", actual))
+    }
+
+    // case 4: breaking out of the tail releases the input
+    head, tail = SplitAt(Counter(0), 0)
+    for _ = range tail {
+        break
+    }
+    if actual := collect(tail); len(actual) != 0 {
+        t.Fatal(fmt.Sprintf("expect nothing, actual: %v", actual))
+    }
+    if actual := collect(head); len(actual) != 0 {
+        t.Fatal(fmt.Sprintf("expect nothing, actual: %v", actual))
+    }
+}
+
+func TestSplitAt_Release(t *testing.T) {
+    released := make(chan struct{})
+    source := func(yield func(int) bool) {
+        defer close(released)
+        for i := 1; ; i++ {
+            if !yield(i) {
+                return
+            }
+        }
+    }
+
+    func() {
+        head, _ := SplitAt(source, 2)
+        if actual := head.ToSlice(); !slices.Equal([]int{1, 2}, actual) {
+            t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2}, actual))
+        }
+    }()
+    for i := 0; i < 50; i++ {
+        runtime.GC()
+        select {
+        case <-released:
+            return
+        case <-time.After(10 * time.Millisecond):
+        }
+    }
+    t.Fatal("expect the input iterator to be released after the split iterators are collected")
+}
+
+func TestSpan(t *testing.T) {
+    collect := func(it Iterator[int]) []int {
+        result := []int{}
+        for v := range it {
+            result = append(result, v)
+        }
+        return result
+    }
+    lessThan3 := func(v int) bool { return v < 3 }
+
+    head, tail := Span(Once(Items(1, 2, 5, 1, 2)), lessThan3)
+    if actual := collect(head); !slices.Equal([]int{1, 2}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2}, actual))
+    }
+    if actual := collect(tail); !slices.Equal([]int{5, 1, 2}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{5, 1, 2}, actual))
+    }
+
+    head, tail = Span(Once(Items(1, 2, 5, 1, 2)), lessThan3)
+    if actual := collect(tail); !slices.Equal([]int{5, 1, 2}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{5, 1, 2}, actual))
+    }
+    if actual := collect(head); !slices.Equal([]int{1, 2}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []int{1, 2}, actual))
+    }
+}
+
+func TestAssembleRecords(t *testing.T) {
+    isStart := func(s string) bool { return len(s) > 0 && s[0] != ' ' }
+    lines := []string{"  orphan", "ERROR boom", "  at main.go:1", "  at main.go:2", "INFO ok"}
+
+    actual := []string{}
+    for each := range AssembleRecords(SliceElems(lines), isStart) {
+        actual = append(actual, each)
+    }
+    expect := []string{"  orphan", "ERROR boom\n  at main.go:1\n  at main.go:2", "INFO ok"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %q, actual: %q", expect, actual))
+    }
+
+    for _ = range AssembleRecords(SliceElems(lines), isStart) {
+        break
+    }
+}
@@ -0,0 +1,58 @@
+package goiter
+
+// Stage is a step of a pipeline, it takes an iterator and returns a new one.
+// Stages are created by the curried versions of the operators, such as FilterWith and TakeN,
+// so a pipeline can be assembled from a slice of stages, for example, built from a configuration.
+type Stage[T any] func(Iterator[T]) Iterator[T]
+
+// Pipe applies the stages to the input iterator in order, and returns the resulting iterator.
+// For example:
+//
+//  stages := []goiter.Stage[int]{
+//      goiter.FilterWith(func(v int) bool { return v%2 == 0 }),
+//      goiter.TakeN[int](3),
+//  }
+//  iterator := goiter.Pipe(goiter.Counter(1), stages...)     // iterator will yield 2 4 6
+func Pipe[TIter SeqX[T], T any](iterator TIter, stages ...Stage[T]) Iterator[T] {
+    it := Iterator[T](iterator)
+    for _, stage := range stages {
+        it = stage(it)
+    }
+    return it
+}
+
+// FilterWith is the curried version of Filter function.
+func FilterWith[T any](predicate func(T) bool) Stage[T] {
+    return func(iterator Iterator[T]) Iterator[T] {
+        return Filter(iterator, predicate)
+    }
+}
+
+// TakeN is the curried version of Take function.
+func TakeN[T any](n int) Stage[T] {
+    return func(iterator Iterator[T]) Iterator[T] {
+        return Take(iterator, n)
+    }
+}
+
+// TransformWith is the curried version of Transform function.
+// The returned function is a Stage when TOut is the same as T.
+func TransformWith[T, TOut any](transformer func(T) TOut) func(Iterator[T]) Iterator[TOut] {
+    return func(iterator Iterator[T]) Iterator[TOut] {
+        return Transform(iterator, transformer)
+    }
+}
+
+// ApplyIf applies the stage to the input iterator if cond is true, otherwise it returns the input iterator unchanged.
+// It allows optional stages to be expressed inline in a pipeline.
+// For example:
+//
+//  iterator := goiter.ApplyIf(events, dedup, func(it goiter.Iterator[Event]) goiter.Iterator[Event] {
+//      return goiter.Distinct(it)
+//  })
+func ApplyIf[TIter SeqX[T], T any](iterator TIter, cond bool, stage Stage[T]) Iterator[T] {
+    if !cond {
+        return Iterator[T](iterator)
+    }
+    return stage(Iterator[T](iterator))
+}
@@ -0,0 +1,65 @@
+package goiter
+
+import (
+    "fmt"
+    "slices"
+    "strconv"
+    "testing"
+)
+
+func TestPipe(t *testing.T) {
+    stages := []Stage[int]{
+        FilterWith(func(v int) bool { return v%2 == 0 }),
+        Stage[int](TransformWith(func(v int) int { return v * 10 })),
+        TakeN[int](3),
+    }
+    actual := Pipe(Counter(1), stages...).ToSlice()
+    expect := []int{20, 40, 60}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = Pipe(Range(1, 3)).ToSlice()
+    expect = []int{1, 2, 3}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestFilterWith(t *testing.T) {
+    actual := FilterWith(func(v int) bool { return v > 2 })(Range(1, 5)).ToSlice()
+    expect := []int{3, 4, 5}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestTakeN(t *testing.T) {
+    actual := TakeN[int](2)(Range(1, 5)).ToSlice()
+    expect := []int{1, 2}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestTransformWith(t *testing.T) {
+    actual := TransformWith(strconv.Itoa)(Range(1, 3)).ToSlice()
+    expect := []string{"1", "2", "3"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestApplyIf(t *testing.T) {
+    actual := ApplyIf(Range(1, 5), true, TakeN[int](2)).ToSlice()
+    expect := []int{1, 2}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = ApplyIf(Range(1, 5), false, TakeN[int](2)).ToSlice()
+    expect = []int{1, 2, 3, 4, 5}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
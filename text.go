@@ -0,0 +1,62 @@
+package goiter
+
+import (
+    "unicode"
+    "unicode/utf8"
+)
+
+// Runes returns an iterator that yields the byte offset and the rune of each character in s.
+// Invalid UTF-8 bytes are yielded as utf8.RuneError, one byte at a time, the same as ranging over the string.
+func Runes(s string) Iterator2[int, rune] {
+    return func(yield func(int, rune) bool) {
+        for i, r := range s {
+            if !yield(i, r) {
+                return
+            }
+        }
+    }
+}
+
+// Bytes returns an iterator that yields the index and the value of each byte in s.
+func Bytes(s string) Iterator2[int, byte] {
+    return func(yield func(int, byte) bool) {
+        for i := 0; i < len(s); i++ {
+            if !yield(i, s[i]) {
+                return
+            }
+        }
+    }
+}
+
+// Words returns an iterator that yields the words of s, which are separated by one or more white spaces, like strings.Fields.
+// The words are produced lazily, no slice of all the words is allocated.
+// For example:
+//
+//  iterator := goiter.Words("  hello   goiter ")     // iterator will yield "hello" "goiter"
+func Words(s string) Iterator[string] {
+    return FieldsFunc(s, unicode.IsSpace)
+}
+
+// FieldsFunc is like Words, but the separators are the runes that satisfy f, like strings.FieldsFunc.
+func FieldsFunc(s string, f func(rune) bool) Iterator[string] {
+    return func(yield func(string) bool) {
+        start := -1
+        for i := 0; i < len(s); {
+            r, size := utf8.DecodeRuneInString(s[i:])
+            if f(r) {
+                if start >= 0 {
+                    if !yield(s[start:i]) {
+                        return
+                    }
+                    start = -1
+                }
+            } else if start < 0 {
+                start = i
+            }
+            i += size
+        }
+        if start >= 0 {
+            yield(s[start:])
+        }
+    }
+}
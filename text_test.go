@@ -0,0 +1,63 @@
+package goiter
+
+import (
+    "fmt"
+    "slices"
+    "strings"
+    "testing"
+)
+
+func TestRunes(t *testing.T) {
+    actual := []string{}
+    for i, r := range Runes("a世b") {
+        actual = append(actual, fmt.Sprintf("%d%c", i, r))
+    }
+    expect := []string{"0a", "1世", "4b"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _, _ = range Runes("abc") {
+        break
+    }
+}
+
+func TestBytes(t *testing.T) {
+    actual := []byte{}
+    for _, b := range Bytes("a世") {
+        actual = append(actual, b)
+    }
+    expect := []byte("a世")
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _, _ = range Bytes("abc") {
+        break
+    }
+}
+
+func TestWords(t *testing.T) {
+    for _, s := range []string{"  hello   goiter ", "a\tb\nc", "", "   ", "single", "世界 你好"} {
+        actual := Words(s).ToSlice()
+        expect := strings.Fields(s)
+        if !slices.Equal(expect, actual) {
+            t.Fatal(fmt.Sprintf("expect: %q, actual: %q", expect, actual))
+        }
+    }
+
+    for _ = range Words("a b c") {
+        break
+    }
+}
+
+func TestFieldsFunc(t *testing.T) {
+    isSep := func(r rune) bool { return r == ',' || r == ';' }
+    for _, s := range []string{"a,b;;c", ",,a,", "", "abc"} {
+        actual := FieldsFunc(s, isSep).ToSlice()
+        expect := strings.FieldsFunc(s, isSep)
+        if !slices.Equal(expect, actual) {
+            t.Fatal(fmt.Sprintf("expect: %q, actual: %q", expect, actual))
+        }
+    }
+}
@@ -0,0 +1,340 @@
+package goiter
+
+import (
+    "context"
+    "errors"
+    "iter"
+    "runtime/debug"
+    "sync"
+    "time"
+)
+
+// Watchdog returns an iterator that passes through the values of the input iterator,
+// and calls onStall if the input iterator doesn't produce the next value within maxGap.
+// The parameter of onStall is the 0-based index of the last value produced, or -1 if nothing has been produced yet.
+// Only the time spent waiting for the input iterator is measured, the time spent by the consumer is not.
+// onStall is called at most once per stall from a separate goroutine, the iteration itself is not interrupted.
+// For example:
+//
+//  iterator := goiter.Watchdog(networkSource, 30*time.Second, func(lastIndex int) {
+//      log.Printf("source stalled after element %d", lastIndex)
+//  })
+func Watchdog[TIter SeqX[T], T any](
+    iterator TIter,
+    maxGap time.Duration,
+    onStall func(lastIndex int),
+) Iterator[T] {
+    return func(yield func(T) bool) {
+        lastIndex := -1
+        var timer *time.Timer
+        arm := func() {
+            idx := lastIndex
+            timer = time.AfterFunc(maxGap, func() {
+                onStall(idx)
+            })
+        }
+
+        arm()
+        defer func() {
+            timer.Stop()
+        }()
+        for v := range iterator {
+            timer.Stop()
+            lastIndex++
+            if !yield(v) {
+                return
+            }
+            arm()
+        }
+    }
+}
+
+// DrainOnShutdown consumes the input iterator and calls handler for each value until the input iterator is exhausted.
+// When ctx is cancelled, instead of stopping immediately, it keeps consuming for up to gracePeriod,
+// so that the values already buffered by the upstream stages can be flushed before the program shuts down.
+// It returns nil if the input iterator is exhausted, or ctx.Err() if the grace period expires first.
+//
+// The input iterator is consumed in a separate goroutine, so a blocked upstream doesn't prevent DrainOnShutdown from returning.
+// In that case the goroutine exits the next time the upstream produces a value.
+// If the input iterator panics, the panic is recovered in that goroutine, and DrainOnShutdown panics with a *PanicError wrapping the panic value.
+func DrainOnShutdown[TIter SeqX[T], T any](
+    ctx context.Context,
+    iterator TIter,
+    handler func(T),
+    gracePeriod time.Duration,
+) error {
+    values := make(chan T)
+    finished := make(chan struct{})
+    panics := make(chan *PanicError, 1)
+    quit := make(chan struct{})
+    defer close(quit)
+    go func() {
+        defer close(finished)
+        defer func() {
+            if r := recover(); r != nil {
+                panics <- &PanicError{Value: r, Stack: debug.Stack()}
+            }
+        }()
+        for v := range iterator {
+            select {
+            case values <- v:
+            case <-quit:
+                return
+            }
+        }
+    }()
+
+    done := ctx.Done()
+    var graceExpired <-chan time.Time
+    for {
+        select {
+        case v := <-values:
+            handler(v)
+        case <-finished:
+            repanic(panics)
+            return nil
+        case <-done:
+            done = nil
+            timer := time.NewTimer(gracePeriod)
+            defer timer.Stop()
+            graceExpired = timer.C
+        case <-graceExpired:
+            return ctx.Err()
+        }
+    }
+}
+
+// Tick returns an iterator that yields the current time every interval, backed by a time.Ticker.
+// The iteration stops when ctx is done, and the ticker is stopped as soon as the iteration stops, including when the consumer breaks out of the loop.
+// If interval is not positive, it yields nothing.
+// For example:
+//
+//  for t := range goiter.Tick(ctx, time.Second) {
+//      fmt.Println("tick at", t)
+//  }
+func Tick(ctx context.Context, interval time.Duration) Iterator[time.Time] {
+    if interval <= 0 {
+        return Empty[time.Time]()
+    }
+    return func(yield func(time.Time) bool) {
+        ticker := time.NewTicker(interval)
+        defer ticker.Stop()
+        for {
+            select {
+            case <-ctx.Done():
+                return
+            case t := <-ticker.C:
+                if ctx.Err() != nil {
+                    return
+                }
+                if !yield(t) {
+                    return
+                }
+            }
+        }
+    }
+}
+
+// ErrElementTimeout is the error yielded by TimeoutPerElement, when the input iterator takes too long to produce an element.
+var ErrElementTimeout = errors.New("goiter: timed out waiting for the next element")
+
+// TimeoutPerElement returns an iterator that passes through the values of the input iterator with a nil error,
+// but if the input iterator takes longer than d to produce any single element, it yields ErrElementTimeout as the last element and stops.
+// The input iterator runs in a helper goroutine, since a stalled producer cannot be interrupted,
+// after a timeout, the goroutine exits as soon as the input iterator yields again or finishes.
+// If the input iterator panics, the panic is recovered in the helper goroutine, and the consuming goroutine panics with a *PanicError wrapping the panic value.
+// If d is not positive, no timeout is applied.
+// For example:
+//
+//  for v, err := range goiter.TimeoutPerElement(remoteRecords, 5*time.Second) {
+//      if errors.Is(err, goiter.ErrElementTimeout) {
+//          return err
+//      }
+//      ...
+//  }
+func TimeoutPerElement[TIter SeqX[T], T any](iterator TIter, d time.Duration) IteratorE[T] {
+    return func(yield func(T, error) bool) {
+        if d <= 0 {
+            for v := range iterator {
+                if !yield(v, nil) {
+                    return
+                }
+            }
+            return
+        }
+
+        values := make(chan T)
+        finished := make(chan struct{})
+        panics := make(chan *PanicError, 1)
+        done := make(chan struct{})
+        defer close(done)
+        go func() {
+            defer close(finished)
+            defer func() {
+                if r := recover(); r != nil {
+                    panics <- &PanicError{Value: r, Stack: debug.Stack()}
+                }
+            }()
+            for v := range iterator {
+                select {
+                case values <- v:
+                case <-done:
+                    return
+                }
+            }
+        }()
+
+        timer := time.NewTimer(d)
+        defer timer.Stop()
+        for {
+            select {
+            case v := <-values:
+                if !yield(v, nil) {
+                    return
+                }
+                timer.Reset(d)
+            case <-finished:
+                repanic(panics)
+                return
+            case <-timer.C:
+                var zero T
+                yield(zero, ErrElementTimeout)
+                return
+            }
+        }
+    }
+}
+
+// RateLimit returns an iterator that passes through the values of the input iterator at no more than n values per the duration "per",
+// by a token bucket which holds at most n tokens and starts full, so a burst of up to n values is allowed.
+// When the bucket is empty, it sleeps until a token is available before yielding the next value.
+// If n or per is not positive, the values are passed through without limiting.
+// For example:
+//
+//  for req := range goiter.RateLimit(requests, 10, time.Second) {
+//      callAPI(req)    // at most 10 calls per second
+//  }
+func RateLimit[TIter SeqX[T], T any](iterator TIter, n int, per time.Duration) Iterator[T] {
+    if n <= 0 || per <= 0 {
+        return Iterator[T](iterator)
+    }
+    interval := per / time.Duration(n)
+    return func(yield func(T) bool) {
+        tokens := float64(n)
+        last := time.Now()
+        for v := range iterator {
+            now := time.Now()
+            tokens += float64(now.Sub(last)) / float64(interval)
+            if tokens > float64(n) {
+                tokens = float64(n)
+            }
+            last = now
+            if tokens < 1 {
+                wait := time.Duration((1 - tokens) * float64(interval))
+                time.Sleep(wait)
+                tokens = 1
+                last = time.Now()
+            }
+            tokens--
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+// Throttle returns an iterator that passes through the values of the input iterator, keeping at least minGap between two consecutive values.
+// The time spent by the consumer counts towards the gap.
+// If minGap is not positive, the values are passed through without throttling.
+func Throttle[TIter SeqX[T], T any](iterator TIter, minGap time.Duration) Iterator[T] {
+    if minGap <= 0 {
+        return Iterator[T](iterator)
+    }
+    return func(yield func(T) bool) {
+        var last time.Time
+        for v := range iterator {
+            if !last.IsZero() {
+                if wait := minGap - time.Since(last); wait > 0 {
+                    time.Sleep(wait)
+                }
+            }
+            last = time.Now()
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+// RefreshableSource caches the values of a source and refreshes them after they expire, see Refreshable function for more details.
+type RefreshableSource[T any] struct {
+    mu       sync.Mutex
+    source   func() iter.Seq[T]
+    ttl      time.Duration
+    clock    func() time.Time
+    cached   []T
+    loaded   bool
+    loadedAt time.Time
+}
+
+// Refreshable returns a RefreshableSource, whose Get method returns an iterator that yields the cached values of the source.
+// The values are loaded from the source iterator on first use, and loaded again once they are older than ttl, or after Invalidate is called.
+// It is a common pattern for configuration or reference data that is used inside longer pipelines.
+// The clock function returns the current time, if it is nil, time.Now is used.
+// For example:
+//
+//  countries := goiter.Refreshable(func() goiter.Iterator[Country] {
+//      return loadCountries(db)
+//  }, 10*time.Minute, nil)
+//  for c := range countries.Get() {
+//      ...
+//  }
+func Refreshable[TIter SeqX[T], T any](source func() TIter, ttl time.Duration, clock func() time.Time) *RefreshableSource[T] {
+    if clock == nil {
+        clock = time.Now
+    }
+    return &RefreshableSource[T]{
+        source: func() iter.Seq[T] {
+            return iter.Seq[T](source())
+        },
+        ttl:   ttl,
+        clock: clock,
+    }
+}
+
+// Get returns an iterator that yields the cached values.
+// The freshness is checked each time the iterator is ranged over, so the returned iterator can be kept and reused.
+func (r *RefreshableSource[T]) Get() Iterator[T] {
+    return func(yield func(T) bool) {
+        for _, v := range r.snapshot() {
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+// Invalidate marks the cached values as expired, so they will be loaded again the next time they are used.
+func (r *RefreshableSource[T]) Invalidate() {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.loaded = false
+}
+
+func (r *RefreshableSource[T]) snapshot() []T {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    now := r.clock()
+    if r.loaded && now.Sub(r.loadedAt) < r.ttl {
+        return r.cached
+    }
+    values := make([]T, 0)
+    for v := range r.source() {
+        values = append(values, v)
+    }
+    r.cached = values
+    r.loaded = true
+    r.loadedAt = now
+    return values
+}
@@ -0,0 +1,300 @@
+package goiter
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "slices"
+    "sync"
+    "testing"
+    "time"
+)
+
+func TestWatchdog(t *testing.T) {
+    mu := &sync.Mutex{}
+    stalls := []int{}
+    onStall := func(lastIndex int) {
+        mu.Lock()
+        defer mu.Unlock()
+        stalls = append(stalls, lastIndex)
+    }
+    slowSource := func(yield func(int) bool) {
+        for i := range 4 {
+            if i == 2 {
+                time.Sleep(80 * time.Millisecond)
+            }
+            if !yield(i) {
+                return
+            }
+        }
+    }
+
+    actual := []int{}
+    for v := range Watchdog(slowSource, 20*time.Millisecond, onStall) {
+        actual = append(actual, v)
+        // the time spent by the consumer is not measured
+        time.Sleep(30 * time.Millisecond)
+    }
+    expect := []int{0, 1, 2, 3}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+    mu.Lock()
+    defer mu.Unlock()
+    expectStalls := []int{1}
+    if !slices.Equal(expectStalls, stalls) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expectStalls, stalls))
+    }
+
+    for _ = range Watchdog(slowSource, time.Second, onStall) {
+        break
+    }
+}
+
+func TestDrainOnShutdown(t *testing.T) {
+    // case 1: the input iterator is exhausted
+    actual := []int{}
+    err := DrainOnShutdown(context.Background(), Range(1, 3), func(v int) {
+        actual = append(actual, v)
+    }, time.Second)
+    expect := []int{1, 2, 3}
+    if err != nil || !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v <nil>, actual: %v %v", expect, actual, err))
+    }
+
+    // case 2: buffered values are flushed within the grace period after cancellation
+    ctx, cancel := context.WithCancel(context.Background())
+    source := func(yield func(int) bool) {
+        for i := range 5 {
+            if i == 2 {
+                cancel()
+            }
+            if !yield(i) {
+                return
+            }
+        }
+    }
+    actual = []int{}
+    err = DrainOnShutdown(ctx, source, func(v int) {
+        actual = append(actual, v)
+    }, time.Second)
+    expect = []int{0, 1, 2, 3, 4}
+    if err != nil || !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v <nil>, actual: %v %v", expect, actual, err))
+    }
+
+    // case 3: the grace period expires
+    ctx, cancel = context.WithCancel(context.Background())
+    cancel()
+    count := 0
+    err = DrainOnShutdown(ctx, Counter(0), func(v int) {
+        count++
+        time.Sleep(time.Millisecond)
+    }, 30*time.Millisecond)
+    if !errors.Is(err, context.Canceled) || count == 0 {
+        t.Fatal(fmt.Sprintf("expect context.Canceled after handling some values, actual: %v %d", err, count))
+    }
+
+    // case 4: the input iterator panics
+    errBoom := errors.New("boom")
+    panicking := func(yield func(int) bool) {
+        if yield(1) {
+            panic(errBoom)
+        }
+    }
+    actual = []int{}
+    func() {
+        defer func() {
+            r := recover()
+            if p, ok := r.(*PanicError); !ok || !errors.Is(p, errBoom) || !slices.Equal([]int{1}, actual) {
+                t.Fatal(fmt.Sprintf("expect [1] and a PanicError wrapping errBoom, actual: %v, %v", actual, r))
+            }
+        }()
+        _ = DrainOnShutdown(context.Background(), panicking, func(v int) {
+            actual = append(actual, v)
+        }, time.Second)
+        t.Fatal("expect the panic to reach the caller")
+    }()
+}
+
+func TestTick(t *testing.T) {
+    count := 0
+    last := time.Time{}
+    for tm := range Tick(context.Background(), time.Millisecond) {
+        if !tm.After(last) {
+            t.Fatal(fmt.Sprintf("expect increasing times, actual: %v after %v", tm, last))
+        }
+        last = tm
+        count++
+        if count == 3 {
+            break
+        }
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+    defer cancel()
+    count = 0
+    for _ = range Tick(ctx, time.Millisecond) {
+        count++
+    }
+    if count == 0 || ctx.Err() == nil {
+        t.Fatal(fmt.Sprintf("expect ticks until ctx is done, actual: %d ticks, %v", count, ctx.Err()))
+    }
+
+    for _ = range Tick(context.Background(), 0) {
+        t.Fatal("expect nothing for a non-positive interval")
+    }
+}
+
+func TestTimeoutPerElement(t *testing.T) {
+    values, err := CollectE(TimeoutPerElement(Range(1, 3), time.Second))
+    if !slices.Equal([]int{1, 2, 3}, values) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [1 2 3] and no error, actual: %v, %v", values, err))
+    }
+
+    release := make(chan struct{})
+    defer close(release)
+    stalled := func(yield func(int) bool) {
+        for i := 1; i <= 3; i++ {
+            if i == 3 {
+                <-release
+            }
+            if !yield(i) {
+                return
+            }
+        }
+    }
+    start := time.Now()
+    values, err = CollectE(TimeoutPerElement(stalled, 20*time.Millisecond))
+    if !slices.Equal([]int{1, 2}, values) || !errors.Is(err, ErrElementTimeout) {
+        t.Fatal(fmt.Sprintf("expect [1 2] and ErrElementTimeout, actual: %v, %v", values, err))
+    }
+    if time.Since(start) > time.Second {
+        t.Fatal("expect the timeout to fire promptly")
+    }
+
+    values, err = CollectE(TimeoutPerElement(Range(1, 3), 0))
+    if !slices.Equal([]int{1, 2, 3}, values) || err != nil {
+        t.Fatal(fmt.Sprintf("expect [1 2 3] and no error, actual: %v, %v", values, err))
+    }
+
+    for _, _ = range TimeoutPerElement(Counter(0), time.Second) {
+        break
+    }
+
+    errBoom := errors.New("boom")
+    panicking := func(yield func(int) bool) {
+        if yield(1) {
+            panic(errBoom)
+        }
+    }
+    values = nil
+    func() {
+        defer func() {
+            r := recover()
+            if p, ok := r.(*PanicError); !ok || !errors.Is(p, errBoom) || !slices.Equal([]int{1}, values) {
+                t.Fatal(fmt.Sprintf("expect [1] and a PanicError wrapping errBoom, actual: %v, %v", values, r))
+            }
+        }()
+        for v, _ := range TimeoutPerElement(panicking, time.Second) {
+            values = append(values, v)
+        }
+        t.Fatal("expect the panic to reach the consumer")
+    }()
+}
+
+func TestRateLimit(t *testing.T) {
+    start := time.Now()
+    actual := RateLimit(Range(1, 6), 3, 60*time.Millisecond).ToSlice()
+    elapsed := time.Since(start)
+    if !slices.Equal([]int{1, 2, 3, 4, 5, 6}, actual) {
+        t.Fatal(fmt.Sprintf("expect all values, actual: %v", actual))
+    }
+    // the first 3 values are a burst, the next 3 are 20ms apart
+    if elapsed < 55*time.Millisecond {
+        t.Fatal(fmt.Sprintf("expect the values to be rate limited, actual: %v elapsed", elapsed))
+    }
+
+    start = time.Now()
+    if Count(RateLimit(Range(1, 3), 3, time.Hour)) != 3 || time.Since(start) > 100*time.Millisecond {
+        t.Fatal("expect a burst of n values without waiting")
+    }
+
+    if Count(RateLimit(Range(1, 3), 0, time.Second)) != 3 {
+        t.Fatal("expect no limiting for a non-positive n")
+    }
+
+    for _ = range RateLimit(Range(1, 3), 1, time.Millisecond) {
+        break
+    }
+}
+
+func TestThrottle(t *testing.T) {
+    start := time.Now()
+    actual := Throttle(Range(1, 4), 15*time.Millisecond).ToSlice()
+    elapsed := time.Since(start)
+    if !slices.Equal([]int{1, 2, 3, 4}, actual) {
+        t.Fatal(fmt.Sprintf("expect all values, actual: %v", actual))
+    }
+    if elapsed < 45*time.Millisecond {
+        t.Fatal(fmt.Sprintf("expect at least 3 gaps of 15ms, actual: %v elapsed", elapsed))
+    }
+
+    if Count(Throttle(Range(1, 3), 0)) != 3 {
+        t.Fatal("expect no throttling for a non-positive gap")
+    }
+
+    for _ = range Throttle(Range(1, 3), time.Millisecond) {
+        break
+    }
+}
+
+func TestRefreshable(t *testing.T) {
+    now := time.Unix(1000, 0)
+    clock := func() time.Time { return now }
+    loads := 0
+    r := Refreshable(func() Iterator[int] {
+        loads++
+        return Range(loads, loads+2)
+    }, time.Minute, clock)
+
+    it := r.Get()
+    actual := []int{}
+    for v := range it {
+        actual = append(actual, v)
+    }
+    expect := []int{1, 2, 3}
+    if !slices.Equal(expect, actual) {
+        t.Fatalf("test Refreshable, expect %v, got %v", expect, actual)
+    }
+
+    now = now.Add(30 * time.Second)
+    actual = []int{}
+    for v := range it {
+        actual = append(actual, v)
+    }
+    if !slices.Equal(expect, actual) || loads != 1 {
+        t.Fatalf("test Refreshable, expect cached %v, got %v after %d loads", expect, actual, loads)
+    }
+
+    now = now.Add(time.Minute)
+    actual = []int{}
+    for v := range r.Get() {
+        actual = append(actual, v)
+    }
+    expect = []int{2, 3, 4}
+    if !slices.Equal(expect, actual) {
+        t.Fatalf("test Refreshable, expect refreshed %v, got %v", expect, actual)
+    }
+
+    r.Invalidate()
+    actual = []int{}
+    for v := range it {
+        actual = append(actual, v)
+        break
+    }
+    expect = []int{3}
+    if !slices.Equal(expect, actual) || loads != 3 {
+        t.Fatalf("test Refreshable, expect invalidated %v, got %v after %d loads", expect, actual, loads)
+    }
+}
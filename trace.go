@@ -0,0 +1,80 @@
+package goiter
+
+import (
+    "context"
+    "log/slog"
+    "time"
+)
+
+// TraceOption configures Trace.
+type TraceOption func(*traceConfig)
+
+type traceConfig struct {
+    elementLevel slog.Level
+    summaryLevel slog.Level
+}
+
+// WithTraceElementLevel sets the level of the log records of the elements, the default is slog.LevelDebug.
+func WithTraceElementLevel(level slog.Level) TraceOption {
+    return func(c *traceConfig) {
+        c.elementLevel = level
+    }
+}
+
+// WithTraceSummaryLevel sets the level of the log record written when the iteration ends, the default is slog.LevelInfo.
+func WithTraceSummaryLevel(level slog.Level) TraceOption {
+    return func(c *traceConfig) {
+        c.summaryLevel = level
+    }
+}
+
+// Trace returns an iterator that passes through the values of the input iterator, and logs them to logger.
+// Each yielded element is logged with its index, and when the iteration ends, the total count and the duration are logged,
+// along with whether the consumer stopped early. All the records carry the label, so the stages of a pipeline can be told apart.
+// If logger is nil, slog.Default() is used.
+// For example:
+//
+//  iterator := goiter.Trace(goiter.Filter(records, isValid), logger, "valid records")
+func Trace[TIter SeqX[T], T any](iterator TIter, logger *slog.Logger, label string, opts ...TraceOption) Iterator[T] {
+    config := &traceConfig{
+        elementLevel: slog.LevelDebug,
+        summaryLevel: slog.LevelInfo,
+    }
+    for _, opt := range opts {
+        opt(config)
+    }
+
+    return func(yield func(T) bool) {
+        l := logger
+        if l == nil {
+            l = slog.Default()
+        }
+        ctx := context.Background()
+        start := time.Now()
+        count := 0
+        stopped := false
+        defer func() {
+            l.Log(ctx, config.summaryLevel, "goiter: iteration finished",
+                slog.String("label", label),
+                slog.Int("count", count),
+                slog.Duration("duration", time.Since(start)),
+                slog.Bool("stopped_early", stopped),
+            )
+        }()
+
+        for v := range iterator {
+            if l.Enabled(ctx, config.elementLevel) {
+                l.Log(ctx, config.elementLevel, "goiter: element",
+                    slog.String("label", label),
+                    slog.Int("index", count),
+                    slog.Any("value", v),
+                )
+            }
+            count++
+            if !yield(v) {
+                stopped = true
+                return
+            }
+        }
+    }
+}
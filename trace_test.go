@@ -0,0 +1,43 @@
+package goiter
+
+import (
+    "bytes"
+    "fmt"
+    "log/slog"
+    "strings"
+    "testing"
+)
+
+func TestTrace(t *testing.T) {
+    buf := &bytes.Buffer{}
+    logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+    for v := range Trace(Range(1, 5), logger, "numbers") {
+        if v == 2 {
+            break
+        }
+    }
+    lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+    if len(lines) != 3 {
+        t.Fatal(fmt.Sprintf("expect 3 log lines, actual: %q", lines))
+    }
+    if !strings.Contains(lines[1], "level=DEBUG") || !strings.Contains(lines[1], "label=numbers index=1 value=2") {
+        t.Fatal(fmt.Sprintf("unexpected element log: %s", lines[1]))
+    }
+    if !strings.Contains(lines[2], "level=INFO") || !strings.Contains(lines[2], "count=2") || !strings.Contains(lines[2], "stopped_early=true") {
+        t.Fatal(fmt.Sprintf("unexpected summary log: %s", lines[2]))
+    }
+
+    buf.Reset()
+    logger = slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+    actual := Trace(Range(1, 3), logger, "quiet", WithTraceSummaryLevel(slog.LevelWarn)).ToSlice()
+    lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+    if len(actual) != 3 || len(lines) != 1 || !strings.Contains(lines[0], "level=WARN") || !strings.Contains(lines[0], "stopped_early=false") {
+        t.Fatal(fmt.Sprintf("expect a single summary line at WARN level, actual: %q", lines))
+    }
+
+    buf.Reset()
+    Trace(Range(1, 2), logger, "loud", WithTraceElementLevel(slog.LevelError)).ToSlice()
+    if n := strings.Count(buf.String(), "level=ERROR"); n != 2 {
+        t.Fatal(fmt.Sprintf("expect 2 element lines at ERROR level, actual: %d", n))
+    }
+}
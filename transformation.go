@@ -1,7 +1,8 @@
 package goiter
 
 import (
-    "iter"
+    "errors"
+    "strconv"
 )
 
 // PickV1 returns an iterator that yields the first element of each 2-tuple provided by the input iterator.
@@ -43,13 +44,7 @@ func Transform[TIter SeqX[T], TOut, T any](
     transformer func(T) TOut,
 ) Iterator[TOut] {
     return func(yield func(TOut) bool) {
-        next, stop := iter.Pull(iter.Seq[T](iterator))
-        defer stop()
-        for {
-            v, ok := next()
-            if !ok {
-                return
-            }
+        for v := range iterator {
             out := transformer(v)
             if !yield(out) {
                 return
@@ -64,13 +59,7 @@ func Transform2[TIter Seq2X[T1, T2], TOut1, TOut2, T1, T2 any](
     transformer func(T1, T2) (TOut1, TOut2),
 ) Iterator2[TOut1, TOut2] {
     return func(yield func(TOut1, TOut2) bool) {
-        next, stop := iter.Pull2(iter.Seq2[T1, T2](iterator))
-        defer stop()
-        for {
-            v1, v2, ok := next()
-            if !ok {
-                return
-            }
+        for v1, v2 := range iterator {
             out1, out2 := transformer(v1, v2)
             if !yield(out1, out2) {
                 return
@@ -79,6 +68,29 @@ func Transform2[TIter Seq2X[T1, T2], TOut1, TOut2, T1, T2 any](
     }
 }
 
+// TransformV1 is like Transform2, but it transforms only the first element of each 2-tuple, the second element is passed through.
+// For example:
+//  iterator := goiter.Slice([]string{"a", "b"})                // iterator will yield (0, "a") (1, "b")
+//  newIterator := goiter.TransformV1(iterator, strconv.Itoa)   // after calling TransformV1, newIterator will yield ("0", "a") ("1", "b")
+func TransformV1[TIter Seq2X[T1, T2], TOut, T1, T2 any](
+    iterator TIter,
+    transformer func(T1) TOut,
+) Iterator2[TOut, T2] {
+    return Transform2(iterator, func(v1 T1, v2 T2) (TOut, T2) {
+        return transformer(v1), v2
+    })
+}
+
+// TransformV2 is like TransformV1, but it transforms only the second element of each 2-tuple.
+func TransformV2[TIter Seq2X[T1, T2], TOut, T1, T2 any](
+    iterator TIter,
+    transformer func(T2) TOut,
+) Iterator2[T1, TOut] {
+    return Transform2(iterator, func(v1 T1, v2 T2) (T1, TOut) {
+        return v1, transformer(v2)
+    })
+}
+
 // Transform12 is similar to Transform, but it transforms each value from the input iterator to 2-tuple values.
 // For example:
 //  iterator := goiter.SliceElems([]string{"hello", "golang"})               // iterator will yield "hello" "golang"
@@ -90,13 +102,7 @@ func Transform12[TIter SeqX[T], OutT1, OutT2, T any](
     transformer func(T) (OutT1, OutT2),
 ) Iterator2[OutT1, OutT2] {
     return func(yield func(OutT1, OutT2) bool) {
-        next, stop := iter.Pull(iter.Seq[T](iterator))
-        defer stop()
-        for {
-            v, ok := next()
-            if !ok {
-                return
-            }
+        for v := range iterator {
             out1, out2 := transformer(v)
             if !yield(out1, out2) {
                 return
@@ -111,13 +117,7 @@ func Transform21[TIter Seq2X[T1, T2], TOut, T1, T2 any](
     transformer func(T1, T2) TOut,
 ) Iterator[TOut] {
     return func(yield func(TOut) bool) {
-        next, stop := iter.Pull2(iter.Seq2[T1, T2](iterator))
-        defer stop()
-        for {
-            v1, v2, ok := next()
-            if !ok {
-                return
-            }
+        for v1, v2 := range iterator {
             out := transformer(v1, v2)
             if !yield(out) {
                 return
@@ -125,3 +125,132 @@ func Transform21[TIter Seq2X[T1, T2], TOut, T1, T2 any](
         }
     }
 }
+
+// Tap returns an iterator that calls f for each value of the input iterator before yielding it, the values are not altered.
+// It is useful for debugging and collecting metrics in the middle of a pipeline.
+// For example:
+//
+//  processed := 0
+//  iterator := goiter.Tap(records, func(r Record) {
+//      processed++
+//  })
+func Tap[TIter SeqX[T], T any](iterator TIter, f func(T)) Iterator[T] {
+    return func(yield func(T) bool) {
+        for v := range iterator {
+            f(v)
+            if !yield(v) {
+                return
+            }
+        }
+    }
+}
+
+// Tap2 is the iter.Seq2 version of Tap function.
+func Tap2[TIter Seq2X[T1, T2], T1, T2 any](iterator TIter, f func(T1, T2)) Iterator2[T1, T2] {
+    return func(yield func(T1, T2) bool) {
+        for v1, v2 := range iterator {
+            f(v1, v2)
+            if !yield(v1, v2) {
+                return
+            }
+        }
+    }
+}
+
+// Parse returns an iterator that parses each string provided by the input iterator with the parse function,
+// and yields the parsed value along with a nil error, or the zero value along with an error if the parsing fails.
+// The yielded errors are *OverflowError if the underlying error is strconv.ErrRange, or *ParseError otherwise,
+// so the callers can tell which element failed and decide whether to skip it or to stop the iteration.
+// For example:
+//
+//  for v, err := range goiter.Parse(goiter.Items("1", "x", "3"), strconv.Atoi) {
+//      var parseErr *goiter.ParseError
+//      if errors.As(err, &parseErr) {
+//          log.Printf("skip invalid input %q at %d", parseErr.Input, parseErr.Index)
+//          continue
+//      }
+//      fmt.Println(v)  // prints 1 and 3
+//  }
+func Parse[TIter SeqX[string], T any](
+    iterator TIter,
+    parse func(string) (T, error),
+) Iterator2[T, error] {
+    return func(yield func(T, error) bool) {
+        idx := 0
+        for s := range iterator {
+            v, err := parse(s)
+            if err != nil {
+                if errors.Is(err, strconv.ErrRange) {
+                    err = &OverflowError{Index: idx, Input: s, Err: err}
+                } else {
+                    err = &ParseError{Index: idx, Input: s, Err: err}
+                }
+                var zero T
+                v = zero
+            }
+            idx++
+            if !yield(v, err) {
+                return
+            }
+        }
+    }
+}
+
+// TagEveryN returns an iterator that pairs each value provided by the input iterator with a tag,
+// the tags are cycled by position, every n consecutive values share the same tag.
+// It is useful for presentation, such as striping odd and even rows, or grouping rows every n lines.
+// For example:
+//
+//  goiter.TagEveryN(goiter.Items("a", "b", "c", "d", "e"), 2, "odd", "even")
+//  // will yield ("odd", "a") ("odd", "b") ("even", "c") ("even", "d") ("odd", "e")
+//
+// If n is less than or equal to 0, or no tags are provided, it yields nothing.
+func TagEveryN[TIter SeqX[T], T any, TTag any](
+    iterator TIter,
+    n int,
+    tags ...TTag,
+) Iterator2[TTag, T] {
+    if n <= 0 || len(tags) == 0 {
+        return Empty2[TTag, T]()
+    }
+
+    return func(yield func(TTag, T) bool) {
+        idx := 0
+        for v := range iterator {
+            if !yield(tags[(idx/n)%len(tags)], v) {
+                return
+            }
+            idx++
+        }
+    }
+}
+
+// Enumerate returns an iterator that pairs each value of the input iterator with an index, starting from start and incremented by step.
+// It is like WithCounter method, but step can be any integer, including negative ones.
+// For example:
+//
+//  iterator := goiter.Enumerate(goiter.Items("a", "b", "c"), 20, 10)  // iterator will yield (20, "a") (30, "b") (40, "c")
+func Enumerate[TIter SeqX[T], T any](iterator TIter, start, step int) Iterator2[int, T] {
+    return func(yield func(int, T) bool) {
+        idx := start
+        for v := range iterator {
+            if !yield(idx, v) {
+                return
+            }
+            idx += step
+        }
+    }
+}
+
+// Keyed returns an iterator that pairs each value of the input iterator with the key computed by keySelector, the key comes first.
+// It lifts a stream of values into a keyed stream, which is usually the first step of the join and group pipelines.
+// For example:
+//
+//  iterator := goiter.Keyed(users, func(u User) int {
+//      return u.ID
+//  })  // iterator yields (id, user) pairs
+func Keyed[TIter SeqX[T], T any, K any](iterator TIter, keySelector func(T) K) Iterator2[K, T] {
+    return Transform12(iterator, func(v T) (K, T) {
+        return keySelector(v), v
+    })
+}
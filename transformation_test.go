@@ -1,9 +1,12 @@
 package goiter
 
 import (
+    "errors"
     "fmt"
     "maps"
     "slices"
+    "strconv"
+    "strings"
     "testing"
 )
 
@@ -196,3 +199,160 @@ func TestTransform21(t *testing.T) {
         t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
     }
 }
+
+func TestParse(t *testing.T) {
+    parseInt8 := func(s string) (int8, error) {
+        v, err := strconv.ParseInt(s, 10, 8)
+        return int8(v), err
+    }
+
+    values := []int8{}
+    errs := []error{}
+    for v, err := range Parse(Items("1", "x", "300", "4"), parseInt8) {
+        values = append(values, v)
+        errs = append(errs, err)
+    }
+    expect := []int8{1, 0, 0, 4}
+    if !slices.Equal(expect, values) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, values))
+    }
+    if errs[0] != nil || errs[3] != nil {
+        t.Fatal(fmt.Sprintf("expect nil errors, actual: %v", errs))
+    }
+
+    var parseErr *ParseError
+    if !errors.As(errs[1], &parseErr) || parseErr.Index != 1 || parseErr.Input != "x" || !errors.Is(errs[1], strconv.ErrSyntax) {
+        t.Fatal(fmt.Sprintf("unexpected error: %v", errs[1]))
+    }
+    var overflowErr *OverflowError
+    if !errors.As(errs[2], &overflowErr) || overflowErr.Index != 2 || overflowErr.Input != "300" {
+        t.Fatal(fmt.Sprintf("unexpected error: %v", errs[2]))
+    }
+    if errs[1].Error() != `goiter: parsing element 1 "x": strconv.ParseInt: parsing "x": invalid syntax` {
+        t.Fatal(fmt.Sprintf("unexpected error message: %s", errs[1].Error()))
+    }
+
+    for _, _ = range Parse(Items("1", "2"), strconv.Atoi) {
+        break
+    }
+}
+
+func TestTagEveryN(t *testing.T) {
+    actual := []string{}
+    for tag, v := range TagEveryN(Items("a", "b", "c", "d", "e"), 2, "odd", "even") {
+        actual = append(actual, tag+":"+v)
+    }
+    expect := []string{"odd:a", "odd:b", "even:c", "even:d", "odd:e"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    if TagEveryN[Iterator[int], int, string](Range(1, 3), 1).Count() != 0 || TagEveryN(Range(1, 3), 0, "x").Count() != 0 {
+        t.Fatal("expect nothing")
+    }
+
+    for _, _ = range TagEveryN(Range(1, 3), 1, 'a', 'b') {
+        break
+    }
+}
+
+func TestEnumerate(t *testing.T) {
+    actual := []string{}
+    for idx, v := range Items("a", "b", "c").Enumerate(20, 10) {
+        actual = append(actual, fmt.Sprintf("%d%s", idx, v))
+    }
+    expect := []string{"20a", "30b", "40c"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []string{}
+    for idx, v := range Enumerate(Items("a", "b", "c"), 0, -2) {
+        actual = append(actual, fmt.Sprintf("%d%s", idx, v))
+    }
+    expect = []string{"0a", "-2b", "-4c"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    for _, _ = range Enumerate(Items("a", "b"), 0, 1) {
+        break
+    }
+}
+
+func TestKeyed(t *testing.T) {
+    actual := []string{}
+    for k, v := range Keyed(Items("apple", "kiwi"), func(s string) int { return len(s) }) {
+        actual = append(actual, fmt.Sprintf("%d:%s", k, v))
+    }
+    expect := []string{"5:apple", "4:kiwi"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestTransformV1(t *testing.T) {
+    actual := []string{}
+    for k, v := range TransformV1(Slice([]string{"a", "b"}), strconv.Itoa) {
+        actual = append(actual, k+v)
+    }
+    expect := []string{"0a", "1b"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []string{}
+    for k, v := range Slice([]string{"a", "b"}).TransformV1(func(idx int) int { return idx * 10 }) {
+        actual = append(actual, fmt.Sprintf("%d%s", k, v))
+    }
+    expect = []string{"0a", "10b"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestTransformV2(t *testing.T) {
+    actual := []string{}
+    for k, v := range TransformV2(Slice([]string{"a", "bb"}), func(s string) int { return len(s) }) {
+        actual = append(actual, fmt.Sprintf("%d:%d", k, v))
+    }
+    expect := []string{"0:1", "1:2"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []string{}
+    for k, v := range Slice([]string{"a", "b"}).TransformV2(strings.ToUpper) {
+        actual = append(actual, fmt.Sprintf("%d%s", k, v))
+    }
+    expect = []string{"0A", "1B"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestTap(t *testing.T) {
+    seen := []int{}
+    actual := Range(1, 5).Tap(func(v int) {
+        seen = append(seen, v)
+    }).Take(2).ToSlice()
+    if !slices.Equal([]int{1, 2}, actual) || !slices.Equal([]int{1, 2}, seen) {
+        t.Fatal(fmt.Sprintf("expect [1 2] seen and yielded, actual: %v, %v", seen, actual))
+    }
+}
+
+func TestTap2(t *testing.T) {
+    seen := []string{}
+    for _, _ = range Slice([]string{"a", "b", "c"}).Tap(func(idx int, v string) {
+        seen = append(seen, fmt.Sprintf("%d%s", idx, v))
+    }) {
+    }
+    expect := []string{"0a", "1b", "2c"}
+    if !slices.Equal(expect, seen) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, seen))
+    }
+
+    for _, _ = range Tap2(Slice([]string{"a", "b"}), func(int, string) {}) {
+        break
+    }
+}
@@ -0,0 +1,176 @@
+package goiter
+
+// TreeOption configures TreeDFS.
+type TreeOption func(*treeConfig)
+
+type treeConfig struct {
+    postOrder bool
+}
+
+// WithPostOrder makes TreeDFS yield each node after all of its descendants, the default is pre-order, which yields each node before its descendants.
+func WithPostOrder() TreeOption {
+    return func(c *treeConfig) {
+        c.postOrder = true
+    }
+}
+
+// TreeDFS returns an iterator that traverses a tree in depth-first order, starting from root.
+// The children function returns the children of a node in order, it is called lazily, only when the traversal reaches the node.
+// The traversal uses an explicit stack instead of recursion, so deep trees don't overflow the call stack.
+// For example:
+//
+//  // yields all go file nodes under the directory tree
+//  iterator := goiter.TreeDFS(root, func(n *Node) []*Node {
+//      return n.Children
+//  }).Filter(func(n *Node) bool {
+//      return strings.HasSuffix(n.Path, ".go")
+//  })
+//
+// By default, the nodes are yielded in pre-order, use WithPostOrder option to yield them in post-order.
+// The tree must not contain cycles, see GraphDFS for the graphs.
+func TreeDFS[T any](root T, children func(T) []T, opts ...TreeOption) Iterator[T] {
+    config := &treeConfig{}
+    for _, opt := range opts {
+        opt(config)
+    }
+    if config.postOrder {
+        return treePostOrder(root, children)
+    }
+
+    return func(yield func(T) bool) {
+        if !yield(root) {
+            return
+        }
+        stack := []*treeFrame[T]{{children: children(root)}}
+        for len(stack) > 0 {
+            top := stack[len(stack)-1]
+            if top.idx >= len(top.children) {
+                stack = stack[:len(stack)-1]
+                continue
+            }
+            node := top.children[top.idx]
+            top.idx++
+            if !yield(node) {
+                return
+            }
+            stack = append(stack, &treeFrame[T]{children: children(node)})
+        }
+    }
+}
+
+func treePostOrder[T any](root T, children func(T) []T) Iterator[T] {
+    return func(yield func(T) bool) {
+        stack := []*treeFrame[T]{{node: root, children: children(root)}}
+        for len(stack) > 0 {
+            top := stack[len(stack)-1]
+            if top.idx < len(top.children) {
+                node := top.children[top.idx]
+                top.idx++
+                stack = append(stack, &treeFrame[T]{node: node, children: children(node)})
+                continue
+            }
+            stack = stack[:len(stack)-1]
+            if !yield(top.node) {
+                return
+            }
+        }
+    }
+}
+
+type treeFrame[T any] struct {
+    node     T
+    children []T
+    idx      int
+}
+
+// TreeBFS is like TreeDFS, but it traverses the tree in breadth-first order, level by level.
+// For example:
+//
+//  for n := range goiter.TreeBFS(root, func(n *Node) []*Node { return n.Children }) {
+//      ...
+//  }
+func TreeBFS[T any](root T, children func(T) []T) Iterator[T] {
+    return func(yield func(T) bool) {
+        queue := []T{root}
+        for len(queue) > 0 {
+            node := queue[0]
+            var zero T
+            queue[0] = zero
+            queue = queue[1:]
+            if !yield(node) {
+                return
+            }
+            queue = append(queue, children(node)...)
+        }
+    }
+}
+
+// GraphBFS returns an iterator that traverses a graph in breadth-first order, starting from start.
+// The neighbors function returns the nodes adjacent to a node, it is called lazily, only when the traversal reaches the node.
+// Every node is yielded at most once, the visited nodes are remembered, so cycles don't lead to infinite loops.
+// For example:
+//
+//  // yields all packages that main depends on directly or indirectly, including main itself
+//  iterator := goiter.GraphBFS("main", func(pkg string) goiter.Iterator[string] {
+//      return goiter.SliceElems(imports[pkg])
+//  })
+//
+// Note: the visited nodes are kept in memory until the iteration ends.
+func GraphBFS[TIter SeqX[T], T comparable](start T, neighbors func(T) TIter) Iterator[T] {
+    return func(yield func(T) bool) {
+        visited := map[T]bool{start: true}
+        queue := []T{start}
+        for len(queue) > 0 {
+            node := queue[0]
+            queue = queue[1:]
+            if !yield(node) {
+                return
+            }
+            for n := range neighbors(node) {
+                if !visited[n] {
+                    visited[n] = true
+                    queue = append(queue, n)
+                }
+            }
+        }
+    }
+}
+
+// GraphDFS is like GraphBFS, but it traverses the graph in depth-first order,
+// each node is yielded before the nodes reachable from it that have not been visited yet.
+// Like TreeDFS, it uses an explicit stack instead of recursion.
+func GraphDFS[TIter SeqX[T], T comparable](start T, neighbors func(T) TIter) Iterator[T] {
+    return func(yield func(T) bool) {
+        visited := map[T]bool{}
+        visit := func(node T) (*treeFrame[T], bool) {
+            visited[node] = true
+            if !yield(node) {
+                return nil, false
+            }
+            return &treeFrame[T]{children: ToSlice(neighbors(node))}, true
+        }
+
+        frame, ok := visit(start)
+        if !ok {
+            return
+        }
+        stack := []*treeFrame[T]{frame}
+        for len(stack) > 0 {
+            top := stack[len(stack)-1]
+            if top.idx >= len(top.children) {
+                stack = stack[:len(stack)-1]
+                continue
+            }
+            node := top.children[top.idx]
+            top.idx++
+            if visited[node] {
+                continue
+            }
+            frame, ok := visit(node)
+            if !ok {
+                return
+            }
+            stack = append(stack, frame)
+        }
+    }
+}
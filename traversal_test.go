@@ -0,0 +1,140 @@
+package goiter
+
+import (
+    "fmt"
+    "slices"
+    "testing"
+)
+
+type testNode struct {
+    name     string
+    children []*testNode
+}
+
+func testTree() *testNode {
+    //       a
+    //     / | \
+    //    b  c  d
+    //   / \    |
+    //  e   f   g
+    return &testNode{name: "a", children: []*testNode{
+        {name: "b", children: []*testNode{{name: "e"}, {name: "f"}}},
+        {name: "c"},
+        {name: "d", children: []*testNode{{name: "g"}}},
+    }}
+}
+
+func testNodeChildren(n *testNode) []*testNode {
+    return n.children
+}
+
+func testNodeName(n *testNode) string {
+    return n.name
+}
+
+func TestTreeDFS(t *testing.T) {
+    actual := Transform(TreeDFS(testTree(), testNodeChildren), testNodeName).ToSlice()
+    expect := []string{"a", "b", "e", "f", "c", "d", "g"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = Transform(TreeDFS(testTree(), testNodeChildren, WithPostOrder()), testNodeName).ToSlice()
+    expect = []string{"e", "f", "b", "c", "g", "d", "a"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    visited := 0
+    counting := func(n *testNode) []*testNode {
+        visited++
+        return n.children
+    }
+    actual = Transform(TreeDFS(testTree(), counting), testNodeName).Take(3).ToSlice()
+    expect = []string{"a", "b", "e"}
+    if !slices.Equal(expect, actual) || visited != 2 {
+        t.Fatal(fmt.Sprintf("expect: %v with 2 visits, actual: %v with %d visits", expect, actual, visited))
+    }
+    actual = Transform(TreeDFS(testTree(), testNodeChildren, WithPostOrder()), testNodeName).Take(1).ToSlice()
+    if !slices.Equal([]string{"e"}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []string{"e"}, actual))
+    }
+
+    // a very deep tree doesn't overflow the stack
+    depth := Count(TreeDFS(0, func(n int) []int {
+        if n >= 1_000_000 {
+            return nil
+        }
+        return []int{n + 1}
+    }, WithPostOrder()))
+    if depth != 1_000_001 {
+        t.Fatal(fmt.Sprintf("expect: 1000001, actual: %d", depth))
+    }
+}
+
+func TestTreeBFS(t *testing.T) {
+    actual := Transform(TreeBFS(testTree(), testNodeChildren), testNodeName).ToSlice()
+    expect := []string{"a", "b", "c", "d", "e", "f", "g"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = Transform(TreeBFS(testTree(), testNodeChildren), testNodeName).Take(2).ToSlice()
+    expect = []string{"a", "b"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestGraphBFS(t *testing.T) {
+    graph := map[string][]string{
+        "a": {"b", "c"},
+        "b": {"d", "a"},
+        "c": {"d"},
+        "d": {"a", "e"},
+    }
+    neighbors := func(n string) Iterator[string] {
+        return SliceElems(graph[n])
+    }
+
+    actual := GraphBFS("a", neighbors).ToSlice()
+    expect := []string{"a", "b", "c", "d", "e"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = GraphBFS("c", neighbors).Take(2).ToSlice()
+    expect = []string{"c", "d"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+}
+
+func TestGraphDFS(t *testing.T) {
+    graph := map[string][]string{
+        "a": {"b", "c"},
+        "b": {"d", "a"},
+        "c": {"d"},
+        "d": {"a", "e"},
+    }
+    neighbors := func(n string) Iterator[string] {
+        return SliceElems(graph[n])
+    }
+
+    actual := GraphDFS("a", neighbors).ToSlice()
+    expect := []string{"a", "b", "d", "e", "c"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = GraphDFS("a", neighbors).Take(3).ToSlice()
+    expect = []string{"a", "b", "d"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = GraphDFS("x", neighbors).ToSlice()
+    if !slices.Equal([]string{"x"}, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", []string{"x"}, actual))
+    }
+}
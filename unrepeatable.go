@@ -58,6 +58,8 @@ func Once2[TIter Seq2X[T1, T2], T1, T2 any](iterator TIter) Iterator2[T1, T2] {
 // This means you can break out of the iteration midway and then continue iterating from where you left off.
 // You can also iterate over it concurrently; FinishOnce will ensure that all values are yielded exactly once.
 // once all values have been yielded, it will not yield any more values.
+// So it turns any iterator into a work queue: ranging over it from several goroutines distributes the values among them,
+// each value is delivered to exactly one of them, and each goroutine stops when all values have been taken.
 // For example:
 //  iterator := goiter.FinishOnce(goiter.Items(1, 2, 3, 4, 5, 6))   // we have this FinishOnce iterator that yields 1, 2, 3, 4, 5, 6
 //
@@ -75,6 +77,19 @@ func Once2[TIter Seq2X[T1, T2], T1, T2 any](iterator TIter) Iterator2[T1, T2] {
 //  for v := range iterator {   // and in this loop, it will not print anything, because all values have been yielded
 //      fmt.Printf("%d ", v)    // so this line won't be executed
 //  }
+//
+//  jobs := goiter.FinishOnce(loadJobs())
+//  var wg sync.WaitGroup
+//  for i := 0; i < 4; i++ {
+//      wg.Add(1)
+//      go func() {
+//          defer wg.Done()
+//          for job := range jobs {     // the 4 workers take the jobs one by one until all jobs are taken
+//              process(job)
+//          }
+//      }()
+//  }
+//  wg.Wait()
 func FinishOnce[TIter SeqX[T], T any](iterator TIter) Iterator[T] {
     fetchLock := &sync.Mutex{}
     next, stop := iter.Pull(iter.Seq[T](Once(iterator)))
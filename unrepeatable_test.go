@@ -285,3 +285,37 @@ func TestFinishOnce2(t *testing.T) {
         t.Fatal(fmt.Sprintf("\nexpect: %v\nactual: %v", input, actual))
     }
 }
+
+func TestFinishOnce_WorkDistribution(t *testing.T) {
+    jobs := FinishOnce(Range(1, 1000))
+    var mu sync.Mutex
+    var wg sync.WaitGroup
+    taken := make([]int, 0, 1000)
+    for i := 0; i < 4; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for job := range jobs {
+                mu.Lock()
+                taken = append(taken, job)
+                mu.Unlock()
+            }
+        }()
+    }
+    wg.Wait()
+
+    slices.Sort(taken)
+    expect := Range(1, 1000).ToSlice()
+    if !slices.Equal(expect, taken) {
+        t.Fatal(fmt.Sprintf("expect each job to be taken exactly once, actual: %d jobs taken", len(taken)))
+    }
+
+    pairs := FinishOnce2(Slice([]string{"a", "b", "c"}))
+    for _, _ = range pairs {
+        break
+    }
+    keys, vals := pairs.ToSlices()
+    if !slices.Equal([]int{1, 2}, keys) || !slices.Equal([]string{"b", "c"}, vals) {
+        t.Fatal(fmt.Sprintf("expect: [1 2] [b c], actual: %v %v", keys, vals))
+    }
+}
@@ -0,0 +1,81 @@
+package goiter
+
+import (
+    "io/fs"
+)
+
+// WalkOption configures WalkDir.
+type WalkOption func(*walkConfig)
+
+type walkConfig struct {
+    skipDir      func(path string, d fs.DirEntry) bool
+    errorHandler func(path string, err error) error
+}
+
+// WithWalkSkipDir sets the function that decides whether a directory should be skipped,
+// a skipped directory is neither yielded nor descended into.
+func WithWalkSkipDir(skip func(path string, d fs.DirEntry) bool) WalkOption {
+    return func(c *walkConfig) {
+        c.skipDir = skip
+    }
+}
+
+// WithWalkErrorHandler sets the function that is called when WalkDir fails to read a path.
+// If it returns nil, the path is skipped and the walk continues, otherwise the walk stops and the returned error is stored into
+// the errp passed to WalkDir. By default, the paths that cannot be read are skipped silently.
+func WithWalkErrorHandler(handler func(path string, err error) error) WalkOption {
+    return func(c *walkConfig) {
+        c.errorHandler = handler
+    }
+}
+
+// WalkDir returns an iterator that walks the file tree rooted at root in fsys, in the same lexical order as fs.WalkDir,
+// and yields the path and the entry of each file and directory, including root itself.
+// Breaking out of the loop stops the walk. If the error handler set by WithWalkErrorHandler returns an error,
+// the walk stops and the error is stored into *errp. *errp is set to nil when the walk starts,
+// errp can be nil if the error is not needed, then the walk just stops.
+// For example:
+//
+//  var walkErr error
+//  goFiles := goiter.Filter2(goiter.WalkDir(os.DirFS("."), ".", &walkErr, goiter.WithWalkSkipDir(func(path string, d fs.DirEntry) bool {
+//      return d.Name() == ".git"
+//  })), func(path string, d fs.DirEntry) bool {
+//      return !d.IsDir() && strings.HasSuffix(path, ".go")
+//  })
+func WalkDir(fsys fs.FS, root string, errp *error, opts ...WalkOption) Iterator2[string, fs.DirEntry] {
+    config := &walkConfig{
+        errorHandler: func(string, error) error {
+            return nil
+        },
+    }
+    for _, opt := range opts {
+        opt(config)
+    }
+
+    return func(yield func(string, fs.DirEntry) bool) {
+        if errp != nil {
+            *errp = nil
+        }
+        fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
+            if err != nil {
+                if handlerErr := config.errorHandler(path, err); handlerErr != nil {
+                    if errp != nil {
+                        *errp = handlerErr
+                    }
+                    return fs.SkipAll
+                }
+                if d != nil && d.IsDir() {
+                    return fs.SkipDir
+                }
+                return nil
+            }
+            if d.IsDir() && config.skipDir != nil && config.skipDir(path, d) {
+                return fs.SkipDir
+            }
+            if !yield(path, d) {
+                return fs.SkipAll
+            }
+            return nil
+        })
+    }
+}
@@ -0,0 +1,71 @@
+package goiter
+
+import (
+    "errors"
+    "fmt"
+    "io/fs"
+    "slices"
+    "testing"
+    "testing/fstest"
+)
+
+func TestWalkDir(t *testing.T) {
+    fsys := fstest.MapFS{
+        "a.txt":            {},
+        "dir/b.txt":        {},
+        "dir/sub/c.txt":    {},
+        ".git/config":      {},
+        "other/d.txt":      {},
+    }
+
+    var walkErr error
+    actual := []string{}
+    for path := range WalkDir(fsys, ".", &walkErr) {
+        actual = append(actual, path)
+    }
+    expect := []string{".", ".git", ".git/config", "a.txt", "dir", "dir/b.txt", "dir/sub", "dir/sub/c.txt", "other", "other/d.txt"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    actual = []string{}
+    skipGit := WithWalkSkipDir(func(path string, d fs.DirEntry) bool {
+        return d.Name() == ".git"
+    })
+    for path := range WalkDir(fsys, ".", &walkErr, skipGit) {
+        actual = append(actual, path)
+        if path == "dir/b.txt" {
+            break
+        }
+    }
+    expect = []string{".", "a.txt", "dir", "dir/b.txt"}
+    if !slices.Equal(expect, actual) {
+        t.Fatal(fmt.Sprintf("expect: %v, actual: %v", expect, actual))
+    }
+
+    count := 0
+    for _, _ = range WalkDir(fsys, "missing", &walkErr) {
+        count++
+    }
+    if count != 0 || walkErr != nil {
+        t.Fatal(fmt.Sprintf("expect nothing without error, actual: %d entries with error %v", count, walkErr))
+    }
+
+    handled := 0
+    for _, _ = range WalkDir(fsys, "missing", &walkErr, WithWalkErrorHandler(func(path string, err error) error {
+        handled++
+        return fmt.Errorf("walk %s: %w", path, err)
+    })) {
+    }
+    if handled != 1 || !errors.Is(walkErr, fs.ErrNotExist) {
+        t.Fatal(fmt.Sprintf("expect the handler error wrapping fs.ErrNotExist, actual: %v", walkErr))
+    }
+
+    // the walk stops without reporting the error
+    count = Count2(WalkDir(fsys, "missing", nil, WithWalkErrorHandler(func(path string, err error) error {
+        return err
+    })))
+    if count != 0 {
+        t.Fatal(fmt.Sprintf("expect: 0, actual: %d", count))
+    }
+}